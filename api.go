@@ -70,11 +70,7 @@
 // Built on github.com/zoobzio/pipz for advanced pipeline capabilities.
 package zlog
 
-import (
-	"os"
-	"runtime"
-	"time"
-)
+import "context"
 
 // Emit sends an event with the specified signal, message, and optional fields.
 //
@@ -95,31 +91,104 @@ import (
 //	)
 //
 // Emit automatically captures caller information (file, line, function) for
-// debugging. Events are processed asynchronously - Emit returns immediately
-// after routing the event to the appropriate sinks.
+// debugging; see SetCallerMode and SetCallerPath to tune or disable this.
+// Events are processed asynchronously - Emit returns immediately after
+// routing the event to the appropriate sinks.
 func Emit(signal Signal, msg string, fields ...Field) {
-	event := NewEvent(signal, msg, fields)
-
-	// Capture caller information
-	// Skip 2 frames: Emit -> wrapper function (Info/Debug/etc) -> user code
-	// For direct Emit calls, we only skip 1 frame
-	skip := 1
-	if signal == DEBUG || signal == INFO || signal == WARN || signal == ERROR || signal == FATAL {
-		skip = 2
-	}
-
-	if pc, file, line, ok := runtime.Caller(skip); ok {
-		fn := runtime.FuncForPC(pc)
-		if fn != nil {
-			event.Caller = CallerInfo{
-				File:     file,
-				Line:     line,
-				Function: fn.Name(),
-			}
-		}
-	}
-
-	defaultLogger.Process(event)
+	emitFields(getContext(), currentLogger(), 1, signal, msg, nil, fields)
+}
+
+// Enabled reports whether events with the signal are routed to any sink of
+// the default logger. Use it to skip building events nobody receives:
+//
+//	if zlog.Enabled(zlog.DEBUG) {
+//	    zlog.Debug("Cache state", zlog.Data("entries", cache.Snapshot()))
+//	}
+//
+// For a single expensive field, Lazy is usually simpler. Enabled does not
+// evaluate sink filters, but it does honor the level set with SetLevel for
+// the output of EnableStandardLogging.
+func Enabled(signal Signal) bool {
+	return currentLogger().Enabled(signal)
+}
+
+// EmitContext works like Emit but passes ctx to every sink.
+//
+// Use it to carry request-scoped values such as trace IDs to sinks without
+// relying on goroutine-local storage, which breaks as soon as work moves to
+// another goroutine:
+//
+//	func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+//	    zlog.EmitContext(r.Context(), REQUEST_RECEIVED, "Request received",
+//	        zlog.String("path", r.URL.Path))
+//	}
+//
+// Sinks see the context's values but not its cancellation, so events emitted
+// as a request is cancelled are still delivered.
+func EmitContext(ctx context.Context, signal Signal, msg string, fields ...Field) {
+	emitFields(ctx, currentLogger(), 1, signal, msg, nil, fields)
+}
+
+// DebugContext emits a DEBUG event carrying ctx. See EmitContext.
+func DebugContext(ctx context.Context, msg string, fields ...Field) {
+	emitFields(ctx, currentLogger(), 1, DEBUG, msg, nil, fields)
+}
+
+// InfoContext emits an INFO event carrying ctx. See EmitContext.
+func InfoContext(ctx context.Context, msg string, fields ...Field) {
+	emitFields(ctx, currentLogger(), 1, INFO, msg, nil, fields)
+}
+
+// WarnContext emits a WARN event carrying ctx. See EmitContext.
+func WarnContext(ctx context.Context, msg string, fields ...Field) {
+	emitFields(ctx, currentLogger(), 1, WARN, msg, nil, fields)
+}
+
+// ErrorContext emits an ERROR event carrying ctx. See EmitContext.
+func ErrorContext(ctx context.Context, msg string, fields ...Field) {
+	emitFields(ctx, currentLogger(), 1, ERROR, msg, nil, fields)
+}
+
+// FatalContext emits a FATAL event carrying ctx and exits like Fatal.
+func FatalContext(ctx context.Context, msg string, fields ...Field) {
+	l := currentLogger()
+	emitFields(ctx, l, 1, FATAL, msg, nil, fields)
+	exitAfterShutdown(l)
+}
+
+// EmitSkip works like Emit but reports the caller skip frames further up the
+// stack. Use it in helpers that wrap Emit so events point at the helper's
+// caller instead of the helper itself:
+//
+//	func LogPayment(p Payment) {
+//	    zlog.EmitSkip(1, PAYMENT_PROCESSED, "Payment processed",
+//	        zlog.String("id", p.ID))
+//	}
+//
+// A skip of 0 behaves exactly like Emit.
+func EmitSkip(skip int, signal Signal, msg string, fields ...Field) {
+	emitFields(getContext(), currentLogger(), 1+skip, signal, msg, nil, fields)
+}
+
+// WithCallerSkip returns a logger bound to the current default logger that
+// reports callers skip frames further up the stack. It is the counterpart of
+// EmitSkip for helpers that call several logging methods. Call it after any
+// SetDefault so it uses the intended logger.
+func WithCallerSkip(skip int) *FieldsLogger {
+	return Default().WithCallerSkip(skip)
+}
+
+// With returns a child of the current default logger that adds the given
+// fields to every event it emits:
+//
+//	log := zlog.With(zlog.String("request_id", id), zlog.String("component", "api"))
+//	log.Info("Request received")
+//	log.With(zlog.String("step", "auth")).Warn("Token expiring")
+//
+// Like WithCallerSkip, the child is bound to the default logger at the time
+// of the call.
+func With(fields ...Field) *FieldsLogger {
+	return Default().With(fields...)
 }
 
 // Debug emits a debug-level event for development and troubleshooting.
@@ -127,7 +196,7 @@ func Emit(signal Signal, msg string, fields ...Field) {
 //
 //	zlog.Debug("Cache lookup", zlog.String("key", cacheKey))
 func Debug(msg string, fields ...Field) {
-	Emit(DEBUG, msg, fields...)
+	emitFields(getContext(), currentLogger(), 1, DEBUG, msg, nil, fields)
 }
 
 // Info emits an informational event for normal operational messages.
@@ -135,7 +204,7 @@ func Debug(msg string, fields ...Field) {
 //
 //	zlog.Info("Server started", zlog.Int("port", 8080))
 func Info(msg string, fields ...Field) {
-	Emit(INFO, msg, fields...)
+	emitFields(getContext(), currentLogger(), 1, INFO, msg, nil, fields)
 }
 
 // Warn emits a warning event for concerning but recoverable situations.
@@ -143,7 +212,7 @@ func Info(msg string, fields ...Field) {
 //
 //	zlog.Warn("API rate limit approaching", zlog.Int("remaining", 100))
 func Warn(msg string, fields ...Field) {
-	Emit(WARN, msg, fields...)
+	emitFields(getContext(), currentLogger(), 1, WARN, msg, nil, fields)
 }
 
 // Error emits an error event for failures that need attention.
@@ -151,17 +220,17 @@ func Warn(msg string, fields ...Field) {
 //
 //	zlog.Error("Failed to send email", zlog.Err(err), zlog.String("to", email))
 func Error(msg string, fields ...Field) {
-	Emit(ERROR, msg, fields...)
+	emitFields(getContext(), currentLogger(), 1, ERROR, msg, nil, fields)
 }
 
 // Fatal emits a fatal event and terminates the application with os.Exit(1).
 // Use this for unrecoverable errors that prevent the application from continuing.
-// Fatal includes a 100ms delay before exiting to allow sinks to flush.
+// Before exiting, Fatal shuts down the logger so sinks can flush and close,
+// waiting at most a few seconds for them.
 //
 //	zlog.Fatal("Failed to connect to database", zlog.Err(err))
 func Fatal(msg string, fields ...Field) {
-	Emit(FATAL, msg, fields...)
-	// Give pipeline time to flush
-	time.Sleep(100 * time.Millisecond)
-	os.Exit(1)
+	l := currentLogger()
+	emitFields(getContext(), l, 1, FATAL, msg, nil, fields)
+	exitAfterShutdown(l)
 }
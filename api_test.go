@@ -1,6 +1,7 @@
 package zlog
 
 import (
+	"context"
 	"testing"
 )
 
@@ -20,3 +21,17 @@ func TestLogLevelFunctions(t *testing.T) {
 	Error("error message")
 	// Skip Fatal as it calls os.Exit
 }
+
+func TestEnabled(t *testing.T) {
+	original := defaultLogger
+	defaultLogger = NewLogger[Fields]()
+	t.Cleanup(func() { defaultLogger = original })
+
+	if Enabled(ERROR) {
+		t.Error("ERROR should not be enabled without routes")
+	}
+	Hook(ERROR, NewSink("errors", func(_ context.Context, _ Log) error { return nil }))
+	if !Enabled(ERROR) || Enabled(DEBUG) {
+		t.Error("Enabled does not reflect the default logger's routes")
+	}
+}
@@ -13,7 +13,8 @@ import (
 // shouldn't block the main application flow.
 //
 // Important characteristics:
-//   - Fire-and-forget: errors are not reported back to the caller
+//   - Fire-and-forget: errors are not reported back to the caller, only to
+//     the handler set with SetErrorHandler and the SetDeadLetter sink
 //   - No buffering: each event spawns a new goroutine immediately
 //   - No backpressure: unlimited goroutines can be spawned
 //   - Fresh context: background processing uses context.Background()
@@ -36,27 +37,60 @@ import (
 //
 // The original context is not propagated to avoid issues with short-lived
 // contexts (e.g., HTTP request contexts) canceling background work.
+//
+// Flush, Close and Shutdown wait for background goroutines started by this
+// sink to finish, bounded by the context passed to them.
 func (s *Sink) WithAsync() *Sink {
 	// Capture the current processor
 	innerProcessor := s.processor
+	name := s.Name()
+
+	// Track background goroutines so Flush and Shutdown can wait for them
+	pending := &inflight{}
+
+	spawn := pipz.Effect[Log]("async", func(ctx context.Context, event Log) error {
+		pending.add(1)
+
+		// Spawn goroutine for fire-and-forget processing
+		go func() {
+			defer pending.done()
+
+			// Use fresh context since parent might be canceled
+			// This ensures background processing completes even if
+			// the original request/operation has finished
+			asyncCtx := context.Background()
+
+			// Dead letters stay marked so a failing dead-letter sink is
+			// never fed its own events
+			var trace *failureTrace
+			if letter, ok := ctx.Value(deadLetterKey{}).(DeadLetter); ok {
+				asyncCtx = context.WithValue(asyncCtx, deadLetterKey{}, letter)
+			} else if deadLetterSink.Load() != nil {
+				asyncCtx, trace = traceFailures(asyncCtx)
+			}
+
+			// Process in background. Errors are not propagated back to
+			// the caller, so report them here or they are lost.
+			if _, err := innerProcessor.Process(asyncCtx, event); err != nil {
+				reportSinkError(asyncCtx, name, event, err)
+				if trace != nil {
+					trace.deliverGlobal(asyncCtx, name, event, err)
+				}
+			}
+		}()
+
+		// Return immediately with no error
+		// The caller doesn't wait for processing to complete
+		return nil
+	})
+
+	async := s.wrap(asyncLayer{Chainable: spawn, inner: innerProcessor})
+	return async.withResource(&sinkResource{flush: pending.wait})
+}
 
-	return &Sink{
-		processor: pipz.Effect[Log]("async", func(_ context.Context, event Log) error {
-			// Spawn goroutine for fire-and-forget processing
-			go func() {
-				// Use fresh context since parent might be canceled
-				// This ensures background processing completes even if
-				// the original request/operation has finished
-				asyncCtx := context.Background()
-
-				// Process in background, ignoring result
-				// Errors are not propagated back to the caller
-				_, _ = innerProcessor.Process(asyncCtx, event) //nolint:errcheck
-			}()
-
-			// Return immediately with no error
-			// The caller doesn't wait for processing to complete
-			return nil
-		}),
-	}
+// asyncLayer remembers the processor WithAsync hands events to, so
+// DescribeTopology can walk past the background boundary.
+type asyncLayer struct {
+	pipz.Chainable[Log]
+	inner pipz.Chainable[Log]
 }
@@ -47,7 +47,7 @@ func (s *Sink) WithBackoff(maxAttempts int, baseDelay time.Duration) *Sink {
 		baseDelay = 100 * time.Millisecond // Default base delay
 	}
 
-	return &Sink{
-		processor: pipz.NewBackoff("backoff", s.processor, maxAttempts, baseDelay),
-	}
+	return s.layer("backoff", func(inner pipz.Chainable[Log]) pipz.Chainable[Log] {
+		return pipz.NewBackoff("backoff", inner, maxAttempts, baseDelay)
+	})
 }
@@ -2,6 +2,8 @@ package zlog
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"testing"
 	"time"
 )
@@ -68,6 +70,30 @@ func BenchmarkEmit(b *testing.B) {
 	})
 }
 
+// BenchmarkWith measures emitting through a child logger with bound fields.
+// Bound and call-site fields are joined with a single allocation.
+func BenchmarkWith(b *testing.B) {
+	logger := New()
+	logger.Hook(INFO, noOpSink)
+	child := logger.With(String("request_id", "req-1"), String("service", "api"))
+
+	b.Run("BoundOnly", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			child.Info("benchmark message")
+		}
+	})
+
+	b.Run("BoundAndCall", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			child.Info("benchmark message", Int("count", 42), Bool("active", true))
+		}
+	})
+}
+
 // BenchmarkFields measures field creation performance.
 func BenchmarkFields(b *testing.B) {
 	b.Run("String", func(b *testing.B) {
@@ -138,18 +164,8 @@ func BenchmarkSinks(b *testing.B) {
 	})
 
 	b.Run("JSONSink", func(b *testing.B) {
-		// Create a JSON sink that simulates JSON serialization without I/O
-		jsonSink := NewSink("json-bench", func(_ context.Context, e Log) error {
-			// Simulate JSON serialization work
-			data := make(map[string]interface{}, len(e.Data)+3)
-			data["time"] = e.Time
-			data["signal"] = e.Signal
-			data["message"] = e.Message
-			for _, f := range e.Data {
-				data[f.Key] = f.Value
-			}
-			return nil
-		})
+		// Encode with the real JSON encoder, without I/O
+		jsonSink := NewWriterSink(io.Discard, JSONEncoder{})
 		b.ReportAllocs()
 		b.ResetTimer()
 		for i := 0; i < b.N; i++ {
@@ -285,3 +301,69 @@ func BenchmarkRouting(b *testing.B) {
 		}
 	})
 }
+
+// BenchmarkJSONEncoder measures JSONEncoder by field type. The standard
+// field types should encode with zero allocations.
+func BenchmarkJSONEncoder(b *testing.B) {
+	started := time.Date(2024, 3, 1, 11, 59, 59, 0, time.UTC)
+	cases := []struct {
+		name   string
+		fields []Field
+	}{
+		{"NoFields", nil},
+		{"String", []Field{String("user", "alice")}},
+		{"Int", []Field{Int("count", 42)}},
+		{"Float64", []Field{Float64("ratio", 0.75)}},
+		{"Bool", []Field{Bool("cached", true)}},
+		{"Duration", []Field{Duration("latency", 42*time.Millisecond)}},
+		{"Time", []Field{Time("started", started)}},
+		{"Strings", []Field{Strings("tags", []string{"api", "v2", "users"})}},
+		{"Group", []Field{Group("http", String("method", "GET"), Int("status", 200))}},
+		{"Object", []Field{Object("user", testUser{id: "u1", roles: testRoles{"admin"}, age: 36})}},
+		{"Escaped", []Field{String("query", "name = \"o'brien\"\n\tlimit 10")}},
+		{"Typical", []Field{
+			String("method", "GET"),
+			String("path", "/api/users"),
+			Int("status", 200),
+			Duration("latency", 42*time.Millisecond),
+			String("request_id", "req-123"),
+		}},
+	}
+
+	for _, tc := range cases {
+		event := NewEvent(INFO, "request handled", tc.fields)
+		event.Caller = CallerInfo{File: "handler.go", Line: 42}
+
+		b.Run(tc.name, func(b *testing.B) {
+			buf := &Buffer{}
+			encoder := JSONEncoder{}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				buf.Reset()
+				if err := encoder.Encode(event, buf); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+
+	// The map-and-encoding/json approach the encoder replaced, for comparison
+	b.Run("EncodingJSON", func(b *testing.B) {
+		event := NewEvent(INFO, "request handled", cases[len(cases)-1].fields)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			entry := map[string]interface{}{
+				"time":    event.Time,
+				"signal":  event.Signal,
+				"message": event.Message,
+				"caller":  "handler.go:42",
+			}
+			addJSONFields(entry, event.Data)
+			if _, err := json.Marshal(entry); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,234 @@
+package zlog
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// CallerMode controls how events capture their call site.
+type CallerMode int32
+
+const (
+	// CallerEager resolves file, line and function when the event is emitted.
+	// This is the default.
+	CallerEager CallerMode = iota
+
+	// CallerLazy records only the program counter when the event is emitted.
+	// File, line and function are looked up when a sink calls
+	// CallerInfo.Resolve, so events that are never formatted cost almost
+	// nothing. Custom sinks must call Resolve before reading the fields.
+	CallerLazy
+
+	// CallerDisabled skips caller capture entirely, for hot paths where the
+	// call site is not worth the cost.
+	CallerDisabled
+)
+
+// CallerPath controls how the file path of a call site is reported.
+type CallerPath int32
+
+const (
+	// CallerPathFull reports the absolute file path. This is the default.
+	CallerPathFull CallerPath = iota
+
+	// CallerPathBase reports only the file name, e.g. "handler.go".
+	CallerPathBase
+
+	// CallerPathModule reports the path relative to the main module root,
+	// e.g. "internal/api/handler.go" or "cmd/app/main.go". Files outside the
+	// main module are reported by import path, e.g.
+	// "github.com/org/lib/client.go".
+	CallerPathModule
+)
+
+var (
+	callerMode atomic.Int32
+	callerPath atomic.Int32
+)
+
+// SetCallerMode changes how caller information is captured for every logger.
+//
+//	// Hot path - no call sites needed
+//	zlog.SetCallerMode(zlog.CallerDisabled)
+//
+//	// Only pay for file and line when a sink actually prints them
+//	zlog.SetCallerMode(zlog.CallerLazy)
+func SetCallerMode(mode CallerMode) {
+	callerMode.Store(int32(mode))
+}
+
+// SetCallerPath changes how file paths are reported by CallerInfo.Resolve.
+//
+//	zlog.SetCallerPath(zlog.CallerPathModule) // "internal/api/handler.go:42"
+func SetCallerPath(mode CallerPath) {
+	callerPath.Store(int32(mode))
+}
+
+// Resolve fills in File, Line and Function for caller information captured
+// with CallerLazy, applying the configured CallerPath. Already resolved or
+// empty caller information is returned unchanged.
+//
+// Sinks that print the call site should resolve it first:
+//
+//	if caller := event.Caller.Resolve(); caller.File != "" {
+//	    fmt.Fprintf(w, "%s:%d", caller.File, caller.Line)
+//	}
+func (c CallerInfo) Resolve() CallerInfo {
+	if c.pc == 0 || c.File != "" {
+		return c
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{c.pc}).Next()
+	c.File = trimCallerPath(frame.File, frame.Function)
+	c.Line = frame.Line
+	c.Function = frame.Function
+	return c
+}
+
+// captureCallerInfo captures the caller information at the specified skip level.
+func captureCallerInfo(skip int) CallerInfo {
+	if CallerMode(callerMode.Load()) == CallerDisabled {
+		return CallerInfo{}
+	}
+
+	// Skip runtime.Callers and captureCallerInfo itself
+	var pcs [1]uintptr
+	if runtime.Callers(skip+2, pcs[:]) == 0 {
+		return CallerInfo{}
+	}
+	return callerFromPC(pcs[0])
+}
+
+// callerFromPC builds caller information for a program counter recorded
+// elsewhere, such as by log/slog, honoring the configured CallerMode.
+func callerFromPC(pc uintptr) CallerInfo {
+	mode := CallerMode(callerMode.Load())
+	if pc == 0 || mode == CallerDisabled {
+		return CallerInfo{}
+	}
+
+	caller := CallerInfo{pc: pc}
+	if mode == CallerLazy {
+		return caller
+	}
+	return caller.Resolve()
+}
+
+// trimCallerPath shortens a file path according to the configured CallerPath.
+func trimCallerPath(file, function string) string {
+	switch CallerPath(callerPath.Load()) {
+	case CallerPathBase:
+		return filepath.Base(file)
+	case CallerPathModule:
+		if rel, ok := mainModuleFile(file); ok {
+			return rel
+		}
+
+		// Outside the main module, or its sources are not on this machine
+		pkg := packagePath(function)
+		if pkg == "" || pkg == "main" {
+			return filepath.Base(file)
+		}
+		if module := mainModule(); module != "" {
+			if pkg == module {
+				return filepath.Base(file)
+			}
+			if rel, ok := strings.CutPrefix(pkg, module+"/"); ok {
+				pkg = rel
+			}
+		}
+		return path.Join(pkg, filepath.Base(file))
+	default:
+		return file
+	}
+}
+
+// mainModuleFile returns file relative to the root of the main module, e.g.
+// "cmd/app/main.go". It reports false for files of other modules, and when
+// the main module's go.mod cannot be found because the binary runs away
+// from its sources.
+func mainModuleFile(file string) (string, bool) {
+	module := mainModule()
+	if module == "" {
+		return "", false
+	}
+
+	// Builds with -trimpath record files as "<module path>/<path in module>"
+	if rel, ok := strings.CutPrefix(filepath.ToSlash(file), module+"/"); ok {
+		return rel, true
+	}
+
+	root := moduleRoot(filepath.Dir(file))
+	if root == "" {
+		return "", false
+	}
+	rel, err := filepath.Rel(root, file)
+	if err != nil {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// moduleRoots caches moduleRoot by directory.
+var moduleRoots sync.Map
+
+// moduleRoot returns the root directory of the main module if dir is inside
+// it, or "" otherwise. It looks for the nearest go.mod above dir.
+func moduleRoot(dir string) string {
+	if root, ok := moduleRoots.Load(dir); ok {
+		return root.(string) //nolint:errcheck // Only strings are stored
+	}
+
+	root := ""
+	for current := dir; ; {
+		if data, err := os.ReadFile(filepath.Join(current, "go.mod")); err == nil {
+			if modulePath(data) == mainModule() {
+				root = current
+			}
+			break
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+
+	moduleRoots.Store(dir, root)
+	return root
+}
+
+// modulePath returns the path from the module directive of a go.mod file.
+func modulePath(gomod []byte) string {
+	for _, line := range strings.Split(string(gomod), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
+
+// packagePath extracts the import path from a fully qualified function name,
+// e.g. "github.com/org/app/api.(*Server).handle" yields "github.com/org/app/api".
+func packagePath(function string) string {
+	slash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[slash+1:], ".")
+	if dot < 0 {
+		return ""
+	}
+	return function[:slash+1+dot]
+}
+
+// mainModule returns the module path of the running binary, if known.
+var mainModule = sync.OnceValue(func() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Path
+	}
+	return ""
+})
@@ -0,0 +1,163 @@
+package zlog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setCallerConfig applies caller settings for the duration of a test.
+func setCallerConfig(t *testing.T, mode CallerMode, path CallerPath) {
+	t.Helper()
+	SetCallerMode(mode)
+	SetCallerPath(path)
+	t.Cleanup(func() {
+		SetCallerMode(CallerEager)
+		SetCallerPath(CallerPathFull)
+	})
+}
+
+// logViaHelper wraps EmitSkip the way application helpers do.
+func logViaHelper(logger *FieldsLogger, msg string) {
+	logger.EmitSkip(1, INFO, msg)
+}
+
+func TestEmitSkip(t *testing.T) {
+	logger := New()
+	sink, events := captureSink("skip")
+	logger.HookAll(sink)
+
+	logViaHelper(logger, "helper")
+	logger.WithCallerSkip(1).Emit(INFO, "direct") // Skips past the test function
+
+	got := events()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(got))
+	}
+	if !strings.HasSuffix(got[0].Caller.Function, "TestEmitSkip") {
+		t.Errorf("EmitSkip caller = %s, want TestEmitSkip", got[0].Caller.Function)
+	}
+	if strings.HasSuffix(got[1].Caller.Function, "TestEmitSkip") {
+		t.Errorf("WithCallerSkip(1) should report the test's caller, got %s", got[1].Caller.Function)
+	}
+}
+
+func TestWithCallerSkipSharesRouting(t *testing.T) {
+	logger := New()
+	child := logger.WithCallerSkip(1)
+
+	sink, events := captureSink("shared")
+	child.Hook(INFO, sink)
+	logger.Info("parent")
+
+	if len(events()) != 1 {
+		t.Errorf("expected child hook to see parent event, got %d", len(events()))
+	}
+}
+
+func TestCallerModes(t *testing.T) {
+	t.Run("lazy", func(t *testing.T) {
+		setCallerConfig(t, CallerLazy, CallerPathFull)
+
+		caller := captureCallerInfo(0)
+		if caller.File != "" {
+			t.Errorf("lazy capture resolved eagerly: %+v", caller)
+		}
+
+		resolved := caller.Resolve()
+		if filepath.Base(resolved.File) != "caller_test.go" {
+			t.Errorf("resolved file = %s, want caller_test.go", resolved.File)
+		}
+		if resolved.Line == 0 || resolved.Function == "" {
+			t.Errorf("incomplete resolution: %+v", resolved)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		setCallerConfig(t, CallerDisabled, CallerPathFull)
+
+		if caller := captureCallerInfo(0).Resolve(); caller != (CallerInfo{}) {
+			t.Errorf("expected no caller, got %+v", caller)
+		}
+	})
+
+	t.Run("resolve is idempotent", func(t *testing.T) {
+		caller := CallerInfo{File: "main.go", Line: 42}
+		if caller.Resolve() != caller {
+			t.Errorf("Resolve changed resolved caller: %+v", caller.Resolve())
+		}
+	})
+}
+
+func TestCallerPath(t *testing.T) {
+	t.Run("base", func(t *testing.T) {
+		setCallerConfig(t, CallerEager, CallerPathBase)
+
+		if file := captureCallerInfo(0).File; file != "caller_test.go" {
+			t.Errorf("file = %s, want caller_test.go", file)
+		}
+	})
+
+	t.Run("module", func(t *testing.T) {
+		setCallerConfig(t, CallerEager, CallerPathModule)
+
+		file := captureCallerInfo(0).File
+		if filepath.IsAbs(file) || !strings.HasSuffix(file, "caller_test.go") {
+			t.Errorf("file = %s, want module-relative path", file)
+		}
+	})
+}
+
+func TestPackagePath(t *testing.T) {
+	tests := map[string]string{
+		"github.com/org/app/api.(*Server).handle": "github.com/org/app/api",
+		"github.com/org/app.main":                 "github.com/org/app",
+		"main.main":                               "main",
+		"main.main.func1":                         "main",
+		"invalid":                                 "",
+	}
+	for function, want := range tests {
+		if got := packagePath(function); got != want {
+			t.Errorf("packagePath(%q) = %q, want %q", function, got, want)
+		}
+	}
+}
+
+func TestCallerPathModuleMainPackage(t *testing.T) {
+	setCallerConfig(t, CallerEager, CallerPathModule)
+
+	original := mainModule
+	mainModule = func() string { return "example.com/app" }
+	t.Cleanup(func() { mainModule = original })
+
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n\ngo 1.21\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	vendored := filepath.Join(root, "third_party", "lib")
+	if err := os.MkdirAll(vendored, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(vendored, "go.mod"), []byte("module github.com/org/lib\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name, file, function, want string
+	}{
+		{"main in cmd", filepath.Join(root, "cmd", "app", "main.go"), "main.main", "cmd/app/main.go"},
+		{"main at root", filepath.Join(root, "main.go"), "main.main", "main.go"},
+		{"package in module", filepath.Join(root, "internal", "api", "handler.go"), "example.com/app/internal/api.handle", "internal/api/handler.go"},
+		{"trimpath build", "example.com/app/cmd/app/main.go", "main.main", "cmd/app/main.go"},
+		{"other module", filepath.Join(vendored, "client.go"), "github.com/org/lib.Do", "github.com/org/lib/client.go"},
+		{"sources missing", "/nonexistent/build/cmd/app/main.go", "main.main", "main.go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := trimCallerPath(tt.file, tt.function); got != tt.want {
+				t.Errorf("trimCallerPath(%q) = %q, want %q", tt.file, got, tt.want)
+			}
+		})
+	}
+}
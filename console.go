@@ -1,10 +1,10 @@
 package zlog
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
 )
 
@@ -37,29 +37,10 @@ func isTerminal() bool {
 }
 
 // formatSignalWithSymbol returns a colored signal with visual symbol.
+// Custom signals registered with a severity are styled like the built-in
+// signal at or below that severity.
 func formatSignalWithSymbol(signal Signal, useColors bool) string {
-	var symbol, color string
-
-	switch signal {
-	case DEBUG:
-		symbol = "🔍"
-		color = colorGray
-	case INFO:
-		symbol = "✓"
-		color = colorBlue
-	case WARN:
-		symbol = "⚠"
-		color = colorYellow
-	case ERROR:
-		symbol = "✗"
-		color = colorRed
-	case FATAL:
-		symbol = "💀"
-		color = colorRed + colorBold
-	default:
-		symbol = "•"
-		color = colorGray
-	}
+	symbol, color := severityStyle(severityOf(signal))
 
 	if !useColors {
 		return fmt.Sprintf("[%s] %s", string(signal), symbol)
@@ -68,35 +49,109 @@ func formatSignalWithSymbol(signal Signal, useColors bool) string {
 	return fmt.Sprintf("%s[%s]%s %s", color, string(signal), colorReset, symbol)
 }
 
+// severityStyle picks a symbol and color for a severity, using the band of
+// the nearest standard severity at or below it.
+func severityStyle(severity Severity) (symbol, color string) {
+	switch {
+	case severity >= SeverityFatal:
+		return "💀", colorRed + colorBold
+	case severity >= SeverityError:
+		return "✗", colorRed
+	case severity >= SeverityWarn:
+		return "⚠", colorYellow
+	case severity >= SeverityInfo:
+		return "✓", colorBlue
+	case severity >= SeverityDebug:
+		return "🔍", colorGray
+	default:
+		return "•", colorGray
+	}
+}
+
 // formatFields creates a tree-style display of structured fields.
 func formatFields(fields []Field, useColors bool) string {
 	if len(fields) == 0 {
 		return ""
 	}
+	return "\n" + strings.Join(formatFieldTree(fields, "   ", useColors), "\n")
+}
 
+// formatFieldTree renders fields as tree lines under indent. Group members
+// form a subtree beneath their key.
+func formatFieldTree(fields []Field, indent string, useColors bool) []string {
 	var lines []string
 	for i, field := range fields {
 		prefix := "├─"
+		continuation := "│"
 		if i == len(fields)-1 {
 			prefix = "└─"
+			continuation = " "
+		}
+
+		members, nested, err := nestedFields(field)
+		if err != nil {
+			field = NamedErr(field.Key, err)
+			nested = false
+		}
+		if nested {
+			if field.Type == ArrayType {
+				// Elements have no keys, so number them
+				members = indexedFields(members)
+			}
+			if useColors {
+				lines = append(lines, fmt.Sprintf("%s%s%s%s %s%s%s", indent, colorDim, prefix, colorReset, colorBold, field.Key, colorReset))
+			} else {
+				lines = append(lines, fmt.Sprintf("%s%s %s", indent, prefix, field.Key))
+			}
+			lines = append(lines, formatFieldTree(members, indent+continuation+"  ", useColors)...)
+			continue
+		}
+
+		value := field.Value
+		if stack, ok := field.Resolve().Value.(StackTrace); ok && field.Type == StackType {
+			// Frames go on their own lines below the key, kept inside the tree
+			value = formatStack(stack, indent, continuation, useColors)
 		}
 
 		if useColors {
-			line := fmt.Sprintf("   %s%s%s%s=%s%v%s",
-				colorDim, prefix, colorReset,
+			line := fmt.Sprintf("%s%s%s%s%s=%s%v%s",
+				indent, colorDim, prefix, colorReset,
 				colorBold, colorReset,
-				field.Value, colorReset)
+				value, colorReset)
 			lines = append(lines, fmt.Sprintf("%s %s", field.Key, line))
 		} else {
-			lines = append(lines, fmt.Sprintf("   %s %s=%v", prefix, field.Key, field.Value))
+			lines = append(lines, fmt.Sprintf("%s%s %s=%v", indent, prefix, field.Key, value))
 		}
 	}
+	return lines
+}
+
+// indexedFields returns array elements keyed by their position.
+func indexedFields(elements Fields) Fields {
+	indexed := make(Fields, len(elements))
+	for i, element := range elements {
+		element.Key = strconv.Itoa(i)
+		indexed[i] = element
+	}
+	return indexed
+}
 
-	return "\n" + strings.Join(lines, "\n")
+// formatStack renders stack frames indented beneath a tree entry.
+func formatStack(stack StackTrace, indent, continuation string, useColors bool) string {
+	var b strings.Builder
+	for _, frame := range stack {
+		location := fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		if useColors {
+			location = colorDim + location + colorReset
+		}
+		fmt.Fprintf(&b, "\n%s%s     %s\n%s%s         %s", indent, continuation, frame.Function, indent, continuation, location)
+	}
+	return b.String()
 }
 
 // formatCaller formats caller information.
 func formatCaller(caller CallerInfo, useColors bool) string {
+	caller = caller.Resolve()
 	if caller.File == "" {
 		return ""
 	}
@@ -142,34 +197,63 @@ func formatCaller(caller CallerInfo, useColors bool) string {
 //	    return e.Signal != zlog.DEBUG // Hide debug in development
 //	})
 //
+//	// Plain single-line output on stderr
+//	textSink := zlog.NewPrettyConsoleSink(zlog.WithEncoder(zlog.TextEncoder{}))
+//
 // The sink works with all zlog adapters (WithAsync, WithFilter, WithRetry, etc.)
 // and is fully compatible with the fluent builder pattern.
-func NewPrettyConsoleSink() *Sink {
-	useColors := isTerminal()
+func NewPrettyConsoleSink(options ...ConsoleOption) *Sink {
+	config := &consoleConfig{encoder: PrettyEncoder{Colors: isTerminal()}}
+	for _, option := range options {
+		option.applyConsole(config)
+	}
+
+	return newWriterSink("pretty-console", stderrWriter{}, config.encoder)
+}
+
+// ConsoleOption configures NewPrettyConsoleSink and ConsoleJSONSink.
+// WithEncoder is a ConsoleOption.
+type ConsoleOption interface {
+	applyConsole(config *consoleConfig)
+}
+
+// consoleConfig holds configuration for the console sink.
+type consoleConfig struct {
+	encoder Encoder
+}
 
-	return NewSink("pretty-console", func(_ context.Context, event Log) error {
-		// Format timestamp (compact format for readability)
-		timestamp := event.Time.Format("15:04:05")
+// PrettyEncoder formats events for people reading a terminal: the signal
+// with its symbol, a compact timestamp, the message and caller, and the
+// fields as a tree beneath:
+//
+//	[INFO] ✓ 15:04:05 User logged in (auth.go:42)
+//	   ├─ user_id=12345
+//	   └─ session_id=abc123
+//
+// Colors adds ANSI colors. It is the default format of NewPrettyConsoleSink.
+type PrettyEncoder struct {
+	Colors bool
+}
 
-		// Format signal with symbol and color
-		signalDisplay := formatSignalWithSymbol(event.Signal, useColors)
+// Encode implements Encoder.
+func (e PrettyEncoder) Encode(event Log, buf *Buffer) error {
+	// Format timestamp (compact format for readability)
+	timestamp := event.Time.Format("15:04:05")
 
-		// Format caller info
-		callerDisplay := formatCaller(event.Caller, useColors)
+	// Format signal with symbol and color
+	signalDisplay := formatSignalWithSymbol(event.Signal, e.Colors)
 
-		// Format main log line
-		mainLine := fmt.Sprintf("%s %s %s%s",
-			signalDisplay,
-			timestamp,
-			event.Message,
-			callerDisplay)
+	// Format caller info
+	callerDisplay := formatCaller(event.Caller, e.Colors)
 
-		// Format structured fields
-		fieldsDisplay := formatFields(event.Data, useColors)
+	// Format structured fields
+	fieldsDisplay := formatFields(event.Data, e.Colors)
 
-		// Write complete entry to stderr
-		fmt.Fprintf(os.Stderr, "%s%s\n", mainLine, fieldsDisplay)
+	fmt.Fprintf(buf, "%s %s %s%s%s\n", signalDisplay, timestamp, event.Message, callerDisplay, fieldsDisplay)
+	return nil
+}
 
-		return nil
-	})
+// ContentType returns the MIME type of the encoded entries.
+func (PrettyEncoder) ContentType() string {
+	return "text/plain; charset=utf-8"
 }
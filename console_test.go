@@ -187,6 +187,24 @@ func TestFormatFields(t *testing.T) {
 		}
 	})
 
+	t.Run("groups render as subtrees", func(t *testing.T) {
+		fields := []Field{
+			Group("http", String("method", "GET"), Group("response", Int("status", 200))),
+			String("service", "api"),
+		}
+		want := strings.Join([]string{
+			"",
+			"   ├─ http",
+			"   │  ├─ method=GET",
+			"   │  └─ response",
+			"   │     └─ status=200",
+			"   └─ service=api",
+		}, "\n")
+		if got := formatFields(fields, false); got != want {
+			t.Errorf("got:\n%s\nwant:\n%s", got, want)
+		}
+	})
+
 	t.Run("fields without colors", func(t *testing.T) {
 		fields := []Field{String("key", "value")}
 		result := formatFields(fields, false)
@@ -393,3 +411,21 @@ func TestIsTerminal(t *testing.T) {
 		}
 	})
 }
+
+func TestPrettyEncoder(t *testing.T) {
+	buf := &Buffer{}
+	if err := (PrettyEncoder{}).Encode(testEvent("User logged in", String("user_id", "123")), buf); err != nil {
+		t.Fatal(err)
+	}
+
+	got := buf.String()
+	if !strings.Contains(got, "12:00:00 User logged in (auth.go:42)") || !strings.HasSuffix(got, "└─ user_id=123\n") {
+		t.Errorf("unexpected output: %q", got)
+	}
+	if strings.Contains(got, "\033[") {
+		t.Error("expected no color codes without Colors")
+	}
+	if colored := buf.Len(); (PrettyEncoder{Colors: true}).Encode(testEvent("x"), buf) != nil || !strings.Contains(buf.String()[colored:], "\033[") {
+		t.Error("expected color codes with Colors")
+	}
+}
@@ -9,6 +9,11 @@ import (
 // contextStore manages goroutine-local context storage for ephemeral context creation.
 // This allows zlog to propagate context (for distributed tracing, etc.) without
 // requiring users to pass context to every Emit() call.
+//
+// The store is a legacy path kept for existing callers. EmitContext and the
+// other *Context functions pass the context explicitly, which survives
+// goroutine hops and cannot leak entries. When nothing has been stored, the
+// store costs a single map length check per event.
 type contextStore struct {
 	contexts map[int64]context.Context
 	mu       sync.RWMutex
@@ -70,6 +75,10 @@ func getGoroutineID() int64 {
 //	        next.ServeHTTP(w, r)
 //	    })
 //	}
+//
+// Deprecated: Pass the context explicitly with EmitContext, InfoContext and
+// friends. Stored contexts leak if ClearContext is skipped and are lost when
+// work moves to another goroutine.
 func SetContext(ctx context.Context) {
 	if ctx == nil {
 		return
@@ -83,6 +92,8 @@ func SetContext(ctx context.Context) {
 
 // ClearContext removes the stored context for the current goroutine.
 // This should typically be called with defer to ensure cleanup.
+//
+// Deprecated: Pass the context explicitly with EmitContext instead of SetContext.
 func ClearContext() {
 	gid := getGoroutineID()
 	store.mu.Lock()
@@ -93,6 +104,14 @@ func ClearContext() {
 // getContext retrieves the context for the current goroutine.
 // Returns context.Background() if no context has been set.
 func getContext() context.Context {
+	// Skip the goroutine ID lookup entirely when the legacy store is unused
+	store.mu.RLock()
+	empty := len(store.contexts) == 0
+	store.mu.RUnlock()
+	if empty {
+		return context.Background()
+	}
+
 	gid := getGoroutineID()
 	store.mu.RLock()
 	ctx, exists := store.contexts[gid]
@@ -113,6 +132,8 @@ func getContext() context.Context {
 //	defer restore()
 //
 //	zlog.Info("This will use traceCtx")
+//
+// Deprecated: Pass the context explicitly with EmitContext instead.
 func WithContext(ctx context.Context) func() {
 	gid := getGoroutineID()
 
@@ -207,3 +207,87 @@ func BenchmarkContextOperations(b *testing.B) {
 		}
 	})
 }
+
+func TestEmitContext(t *testing.T) {
+	testKey := contextKey("trace")
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), testKey, "abc"))
+	cancel() // Sinks must still receive events for cancelled requests
+
+	var mu sync.Mutex
+	var values []any
+	var errs []error
+	sink := NewSink("emit-context", func(received context.Context, _ Log) error {
+		mu.Lock()
+		values = append(values, received.Value(testKey))
+		errs = append(errs, received.Err())
+		mu.Unlock()
+		return nil
+	})
+
+	logger := New()
+	logger.HookAll(sink)
+
+	// The explicit context wins over one stored for the goroutine
+	SetContext(context.WithValue(context.Background(), testKey, "stored"))
+	defer ClearContext()
+
+	logger.EmitContext(ctx, "CUSTOM", "emit")
+	logger.With(String("k", "v")).InfoContext(ctx, "info")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		logger.ErrorContext(ctx, "other goroutine")
+	}()
+	<-done
+
+	logger.Info("legacy")
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	want := []any{"abc", "abc", "abc", "stored"}
+	if len(values) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(values))
+	}
+	for i := range want {
+		if values[i] != want[i] {
+			t.Errorf("event %d: context value = %v, want %v", i, values[i], want[i])
+		}
+		if errs[i] != nil {
+			t.Errorf("event %d: sink context is cancelled: %v", i, errs[i])
+		}
+	}
+}
+
+func TestPackageEmitContext(t *testing.T) {
+	original := defaultLogger
+	defaultLogger = NewLogger[Fields]()
+	t.Cleanup(func() { defaultLogger = original })
+
+	testKey := contextKey("request")
+	ctx := context.WithValue(context.Background(), testKey, "req-1")
+
+	var mu sync.Mutex
+	var signals []Signal
+	HookAll(NewSink("package-context", func(received context.Context, event Log) error {
+		if received.Value(testKey) == "req-1" {
+			mu.Lock()
+			signals = append(signals, event.Signal)
+			mu.Unlock()
+		}
+		return nil
+	}))
+
+	EmitContext(ctx, "CUSTOM", "emit")
+	DebugContext(ctx, "debug")
+	InfoContext(ctx, "info")
+	WarnContext(ctx, "warn")
+	ErrorContext(ctx, "error")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(signals) != 5 {
+		t.Errorf("expected 5 events with context, got %v", signals)
+	}
+}
@@ -0,0 +1,507 @@
+package zlog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zoobzio/pipz"
+)
+
+// DeadLetter describes why an event reached a dead-letter sink.
+//
+// The dead-letter sink receives the original, unmodified event; the failure
+// metadata travels in the context:
+//
+//	dlq := zlog.NewSink("dlq", func(ctx context.Context, event zlog.Log) error {
+//	    letter, _ := zlog.DeadLetterFromContext(ctx)
+//	    return store.Save(event, letter.Sink, letter.Err, letter.Attempts)
+//	})
+type DeadLetter struct {
+	// FirstFailure and LastFailure bracket the failed attempts. When the
+	// handler was never reached, e.g. because a rate limit rejected the
+	// event, both are the time the event was given up on.
+	FirstFailure time.Time
+	LastFailure  time.Time
+
+	// Err is the error the sink returned. Use errors.Is and errors.As to
+	// inspect the chain.
+	Err error
+
+	// Sink is the name of the sink that gave up on the event.
+	Sink pipz.Name
+
+	// Attempts counts how often the sink's handlers were called, including
+	// fallback handlers.
+	Attempts int
+}
+
+// deadLetterKey carries the DeadLetter for an event sent to a dead-letter sink.
+type deadLetterKey struct{}
+
+// failureKey carries the *failureTrace for an event passing through a sink.
+type failureKey struct{}
+
+// failureTrace records handler attempts for one event passing through a sink.
+type failureTrace struct {
+	first     time.Time
+	last      time.Time
+	attempts  int
+	delivered bool
+	mu        sync.Mutex
+}
+
+// deadLetterSink receives failures from sinks without their own dead letter.
+var deadLetterSink atomic.Pointer[Sink]
+
+// SetDeadLetter sets the sink that receives events any sink failed to handle,
+// after its retries, backoff and fallbacks are exhausted. Sinks with their
+// own WithDeadLetter use that instead. Pass nil to remove it.
+//
+//	zlog.SetDeadLetter(zlog.DeadLetterFile("/var/log/app/dead-letter.ndjson"))
+//
+// Failures of the dead-letter sink itself are reported to the error handler
+// but never dead-lettered again. Shutdown flushes and closes the sink.
+func SetDeadLetter(dlq *Sink) {
+	deadLetterSink.Store(dlq)
+}
+
+// DeadLetterFromContext returns the failure metadata for an event delivered
+// to a dead-letter sink.
+func DeadLetterFromContext(ctx context.Context) (DeadLetter, bool) {
+	letter, ok := ctx.Value(deadLetterKey{}).(DeadLetter)
+	return letter, ok
+}
+
+// WithDeadLetter sends events the sink fails to handle to dlq, together with
+// the failure metadata (see DeadLetterFromContext).
+//
+// Add it after the adapters that give up on events, so the dead letter is
+// only written once everything else has failed:
+//
+//	sink := zlog.NewSink("api", send).
+//	    WithRetry(3).
+//	    WithFallback(backupSink).
+//	    WithDeadLetter(zlog.DeadLetterFile("api-dead-letter.ndjson")).
+//	    WithAsync()
+//
+// The sink's error is still returned and reported to the error handler.
+// Shutdown flushes and closes dlq along with the sink.
+func (s *Sink) WithDeadLetter(dlq *Sink) *Sink {
+	name := s.Name()
+	gated := s.branchLayer("dead-letter", dlq.processor, func(inner pipz.Chainable[Log]) pipz.Chainable[Log] {
+		return deadLetterGate{next: inner, dlq: dlq, sink: name}
+	})
+
+	resources := make([]*sinkResource, 0, len(gated.resources)+len(dlq.resources))
+	resources = append(resources, gated.resources...)
+	resources = append(resources, dlq.resources...)
+
+	counters := make([]*sinkCounters, 0, len(gated.stats.counters)+len(dlq.stats.counters))
+	counters = append(counters, gated.stats.counters...)
+	counters = append(counters, dlq.stats.counters...)
+	dlq.stats.nest()
+
+	return s.derive(gated.processor, counters, resources)
+}
+
+// deadLetterGate sends events its processor fails on to a dead-letter sink.
+type deadLetterGate struct {
+	next pipz.Chainable[Log]
+	dlq  *Sink
+	sink pipz.Name
+}
+
+// Process implements pipz.Chainable.
+func (g deadLetterGate) Process(ctx context.Context, event Log) (Log, error) {
+	ctx, trace := traceFailures(ctx)
+	result, err := g.next.Process(ctx, event)
+	if err != nil {
+		trace.deliver(ctx, g.dlq, g.sink, event, err)
+	}
+	return result, err
+}
+
+// Name implements pipz.Chainable.
+func (g deadLetterGate) Name() pipz.Name {
+	return g.next.Name()
+}
+
+// traceFailures returns the trace for the current event, starting one if the
+// context does not carry it yet.
+func traceFailures(ctx context.Context) (context.Context, *failureTrace) {
+	if trace, ok := ctx.Value(failureKey{}).(*failureTrace); ok && trace != nil {
+		return ctx, trace
+	}
+	trace := &failureTrace{}
+	return context.WithValue(ctx, failureKey{}, trace), trace
+}
+
+// recordAttempt notes a handler call for the event traced by ctx, if any.
+func recordAttempt(ctx context.Context, err error) {
+	if trace, ok := ctx.Value(failureKey{}).(*failureTrace); ok && trace != nil {
+		trace.record(err)
+	}
+}
+
+// record counts one handler call and, if it failed, when.
+func (t *failureTrace) record(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.attempts++
+	if err != nil {
+		now := time.Now()
+		if t.first.IsZero() {
+			t.first = now
+		}
+		t.last = now
+	}
+}
+
+// deliver sends the event to dlq with the trace's failure metadata. Events
+// that are themselves dead letters are never delivered again.
+func (t *failureTrace) deliver(ctx context.Context, dlq *Sink, sink pipz.Name, event Log, err error) {
+	if dlq == nil || ctx.Value(deadLetterKey{}) != nil {
+		return
+	}
+
+	t.mu.Lock()
+	letter := DeadLetter{
+		FirstFailure: t.first,
+		LastFailure:  t.last,
+		Err:          err,
+		Sink:         sink,
+		Attempts:     t.attempts,
+	}
+	t.delivered = true
+	t.mu.Unlock()
+
+	if letter.FirstFailure.IsZero() {
+		letter.FirstFailure = time.Now()
+		letter.LastFailure = letter.FirstFailure
+	}
+
+	// The dead-letter sink starts a trace of its own
+	ctx = context.WithValue(ctx, failureKey{}, (*failureTrace)(nil))
+	ctx = context.WithValue(ctx, deadLetterKey{}, letter)
+	_, _ = dlq.Process(ctx, event) //nolint:errcheck // Failures are reported by the dead-letter sink itself
+}
+
+// deliverGlobal sends the event to the SetDeadLetter sink unless a
+// WithDeadLetter layer already took it.
+func (t *failureTrace) deliverGlobal(ctx context.Context, sink pipz.Name, event Log, err error) {
+	t.mu.Lock()
+	delivered := t.delivered
+	t.mu.Unlock()
+
+	if !delivered {
+		t.deliver(ctx, deadLetterSink.Load(), sink, event, err)
+	}
+}
+
+// deadLetterRecord is one line of a DeadLetterFile.
+type deadLetterRecord struct {
+	Time       time.Time         `json:"time"`
+	Signal     Signal            `json:"signal"`
+	Message    string            `json:"message"`
+	Caller     *deadLetterCaller `json:"caller,omitempty"`
+	Data       []deadLetterField `json:"data"`
+	DeadLetter deadLetterFailure `json:"dead_letter"`
+}
+
+// deadLetterCaller is the resolved caller of a dead-lettered event.
+type deadLetterCaller struct {
+	File     string `json:"file"`
+	Function string `json:"function,omitempty"`
+	Line     int    `json:"line"`
+}
+
+// deadLetterField keeps the field type next to the value so it can be
+// restored on replay.
+type deadLetterField struct {
+	Key   string          `json:"key"`
+	Type  FieldType       `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// deadLetterFailure is the DeadLetter metadata as written to file.
+type deadLetterFailure struct {
+	FirstFailure time.Time `json:"first_failure"`
+	LastFailure  time.Time `json:"last_failure"`
+	Sink         pipz.Name `json:"sink"`
+	Error        string    `json:"error"`
+	ErrorChain   []string  `json:"error_chain,omitempty"`
+	Attempts     int       `json:"attempts"`
+}
+
+// DeadLetterFile creates a sink that appends dead letters to a file as
+// newline-delimited JSON, one event per line:
+//
+//	{"time":"...","signal":"ERROR","message":"Payment failed","data":[...],
+//	 "dead_letter":{"sink":"api","error":"...","error_chain":[...],"attempts":3,...}}
+//
+// Unlike the JSON log sinks, fields keep their type so ReplayDeadLetters can
+// rebuild the events. Use it with WithDeadLetter or SetDeadLetter; events
+// that arrive without dead-letter metadata are written with an empty
+// "dead_letter" object.
+//
+// The file is only ever appended to: it is never rotated or truncated, so no
+// dead letter is lost however large it grows. Move or truncate it yourself
+// once its events have been replayed.
+//
+// Like NewRotatingFileSink, a file that cannot be opened yields a sink that
+// fails every event. Flush syncs the file and Close closes it.
+func DeadLetterFile(path string) *Sink {
+	writer, err := newAppendFileWriter(path)
+	if err != nil {
+		return NewSink("dead-letter-file-failed", func(_ context.Context, _ Log) error {
+			return fmt.Errorf("dead-letter file sink initialization failed: %w", err)
+		})
+	}
+
+	sink := NewSink("dead-letter-file", func(ctx context.Context, event Log) error {
+		record, err := newDeadLetterRecord(ctx, event)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("failed to marshal dead letter: %w", err)
+		}
+		return writer.write(append(data, '\n'))
+	})
+
+	return sink.withResource(&sinkResource{flush: writer.sync, close: writer.close})
+}
+
+// newDeadLetterRecord builds the file record for an event.
+func newDeadLetterRecord(ctx context.Context, event Log) (deadLetterRecord, error) {
+	record := deadLetterRecord{
+		Time:    event.Time,
+		Signal:  event.Signal,
+		Message: event.Message,
+		Data:    make([]deadLetterField, 0, len(event.Data)),
+	}
+
+	if caller := event.Caller.Resolve(); caller.File != "" {
+		record.Caller = &deadLetterCaller{File: caller.File, Function: caller.Function, Line: caller.Line}
+	}
+
+	for _, field := range event.Data {
+		stored := field.Value
+		if fieldErr, ok := stored.(error); ok {
+			// Keep the rendered error so replay can reproduce it
+			stored = newErrorRecord(fieldErr)
+		}
+		if field.Type == ObjectType || field.Type == ArrayType {
+			// Marshalers are stored as they render and replayed as plain data
+			stored = jsonFieldValue(field)
+		}
+		value, err := json.Marshal(stored)
+		if err != nil {
+			return record, fmt.Errorf("failed to marshal field %q: %w", field.Key, err)
+		}
+		record.Data = append(record.Data, deadLetterField{Key: field.Key, Type: field.Type, Value: value})
+	}
+
+	if letter, ok := DeadLetterFromContext(ctx); ok {
+		record.DeadLetter = deadLetterFailure{
+			FirstFailure: letter.FirstFailure,
+			LastFailure:  letter.LastFailure,
+			Sink:         letter.Sink,
+			Attempts:     letter.Attempts,
+		}
+		if letter.Err != nil {
+			record.DeadLetter.Error = letter.Err.Error()
+			record.DeadLetter.ErrorChain = errorChain(letter.Err)
+		}
+	}
+	return record, nil
+}
+
+// errorChain lists the messages of err and everything it wraps, depth first.
+// Wrappers that only repeat the message of what they wrap, like pipz errors,
+// are listed once.
+func errorChain(err error) []string {
+	var chain []string
+	var walk func(err error, parent string)
+	walk = func(err error, parent string) {
+		if err == nil {
+			return
+		}
+		message := err.Error()
+		if message != parent {
+			chain = append(chain, message)
+		}
+		switch wrapped := err.(type) { //nolint:errorlint // Walking the chain by hand
+		case interface{ Unwrap() error }:
+			walk(wrapped.Unwrap(), message)
+		case interface{ Unwrap() []error }:
+			for _, inner := range wrapped.Unwrap() {
+				walk(inner, message)
+			}
+		}
+	}
+	walk(err, "")
+	return chain
+}
+
+// ReplayDeadLetters reads a file written by DeadLetterFile and calls fn for
+// each event and its failure metadata, stopping at the first error:
+//
+//	file, _ := os.Open("api-dead-letter.ndjson")
+//	defer file.Close()
+//	err := zlog.ReplayDeadLetters(file, func(event zlog.Log, _ zlog.DeadLetter) error {
+//	    _, err := apiSink.Process(ctx, event)
+//	    return err
+//	})
+//
+// Field values are restored to the Go types their constructors produce.
+// Data, Object and Array fields come back as the generic values
+// encoding/json decodes into.
+// Error fields come back as errors that render like the originals but no
+// longer match them with errors.Is, and the DeadLetter's Err only carries
+// the original message.
+func ReplayDeadLetters(r io.Reader, fn func(Log, DeadLetter) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var record deadLetterRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return fmt.Errorf("dead letter line %d: %w", line, err)
+		}
+
+		event, letter, err := record.restore()
+		if err != nil {
+			return fmt.Errorf("dead letter line %d: %w", line, err)
+		}
+		if err := fn(event, letter); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// restore rebuilds the event and failure metadata from a record.
+func (r deadLetterRecord) restore() (Log, DeadLetter, error) {
+	event := Log{
+		Time:    r.Time,
+		Signal:  r.Signal,
+		Message: r.Message,
+	}
+	if r.Caller != nil {
+		event.Caller = CallerInfo{File: r.Caller.File, Function: r.Caller.Function, Line: r.Caller.Line}
+	}
+
+	data, err := restoreFields(r.Data)
+	if err != nil {
+		return event, DeadLetter{}, err
+	}
+	event.Data = data
+
+	letter := DeadLetter{
+		FirstFailure: r.DeadLetter.FirstFailure,
+		LastFailure:  r.DeadLetter.LastFailure,
+		Sink:         r.DeadLetter.Sink,
+		Attempts:     r.DeadLetter.Attempts,
+	}
+	if r.DeadLetter.Error != "" {
+		letter.Err = errors.New(r.DeadLetter.Error)
+	}
+	return event, letter, nil
+}
+
+// restoreFields rebuilds fields from their file form.
+func restoreFields(stored []deadLetterField) (Fields, error) {
+	fields := make(Fields, 0, len(stored))
+	for _, field := range stored {
+		value, err := restoreFieldValue(field.Type, field.Value)
+		if err != nil {
+			return fields, fmt.Errorf("field %q: %w", field.Key, err)
+		}
+		fields = append(fields, Field{Key: field.Key, Type: field.Type, Value: value})
+	}
+	return fields, nil
+}
+
+// restoreFieldValue decodes a field value into the type its constructor uses.
+func restoreFieldValue(fieldType FieldType, raw json.RawMessage) (any, error) {
+	if string(raw) == "null" {
+		return nil, nil
+	}
+
+	switch fieldType {
+	case StringType, ByteStringType:
+		return decodeAs[string](raw)
+	case ErrorType:
+		var record errorRecord
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return nil, err
+		}
+		return &replayedError{record: record}, nil
+	case IntType:
+		return decodeAs[int](raw)
+	case Int64Type:
+		return decodeAs[int64](raw)
+	case Int32Type:
+		return decodeAs[int32](raw)
+	case UintType:
+		return decodeAs[uint](raw)
+	case Uint64Type:
+		return decodeAs[uint64](raw)
+	case Float64Type:
+		return decodeAs[float64](raw)
+	case Float32Type:
+		return decodeAs[float32](raw)
+	case BoolType:
+		return decodeAs[bool](raw)
+	case DurationType:
+		return decodeAs[time.Duration](raw)
+	case TimeType:
+		return decodeAs[time.Time](raw)
+	case StringsType:
+		return decodeAs[[]string](raw)
+	case IntsType:
+		return decodeAs[[]int](raw)
+	case Float64sType:
+		return decodeAs[[]float64](raw)
+	case BoolsType:
+		return decodeAs[[]bool](raw)
+	case TimesType:
+		return decodeAs[[]time.Time](raw)
+	case DurationsType:
+		return decodeAs[[]time.Duration](raw)
+	case GroupType:
+		var members []deadLetterField
+		if err := json.Unmarshal(raw, &members); err != nil {
+			return nil, err
+		}
+		return restoreFields(members)
+	case StackType:
+		return decodeAs[StackTrace](raw)
+	default:
+		return decodeAs[any](raw)
+	}
+}
+
+// decodeAs unmarshals raw into a V.
+func decodeAs[V any](raw json.RawMessage) (any, error) {
+	var value V
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
@@ -0,0 +1,321 @@
+package zlog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type deadLetterCapture struct {
+	events  []Log
+	letters []DeadLetter
+	mu      sync.Mutex
+}
+
+func captureDeadLetters(name string, fail error) (*Sink, *deadLetterCapture) {
+	capture := &deadLetterCapture{}
+	sink := NewSink(name, func(ctx context.Context, event Log) error {
+		letter, _ := DeadLetterFromContext(ctx)
+		capture.mu.Lock()
+		capture.events = append(capture.events, event)
+		capture.letters = append(capture.letters, letter)
+		capture.mu.Unlock()
+		return fail
+	})
+	return sink, capture
+}
+
+func (c *deadLetterCapture) snapshot() ([]Log, []DeadLetter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Log(nil), c.events...), append([]DeadLetter(nil), c.letters...)
+}
+
+func useDeadLetter(t *testing.T, dlq *Sink) {
+	t.Helper()
+	SetDeadLetter(dlq)
+	t.Cleanup(func() { SetDeadLetter(nil) })
+}
+
+func TestWithDeadLetter(t *testing.T) {
+	errDown := errors.New("service down")
+	dlq, capture := captureDeadLetters("dlq", nil)
+
+	sink := NewSink("api", func(_ context.Context, _ Log) error { return errDown }).
+		WithRetry(3).
+		WithDeadLetter(dlq)
+
+	event := NewEvent(ERROR, "payment failed", []Field{String("order", "42")})
+	if _, err := sink.Process(context.Background(), event); !errors.Is(err, errDown) {
+		t.Fatalf("expected sink error to be returned, got %v", err)
+	}
+
+	events, letters := capture.snapshot()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d", len(events))
+	}
+	if events[0].Message != event.Message || !reflect.DeepEqual(events[0].Data, event.Data) {
+		t.Errorf("dead letter should be the original event, got %+v", events[0])
+	}
+
+	letter := letters[0]
+	if letter.Sink != "api" || letter.Attempts != 3 || !errors.Is(letter.Err, errDown) {
+		t.Errorf("unexpected dead letter metadata: %+v", letter)
+	}
+	if letter.FirstFailure.IsZero() || letter.LastFailure.Before(letter.FirstFailure) {
+		t.Errorf("unexpected failure times: %s - %s", letter.FirstFailure, letter.LastFailure)
+	}
+}
+
+func TestWithDeadLetterSuccess(t *testing.T) {
+	dlq, capture := captureDeadLetters("dlq", nil)
+	sink := NewSink("ok", func(_ context.Context, _ Log) error { return nil }).WithDeadLetter(dlq)
+
+	_, _ = sink.Process(context.Background(), NewEvent(INFO, "fine", nil)) //nolint:errcheck // Test
+
+	if events, _ := capture.snapshot(); len(events) != 0 {
+		t.Errorf("successful events must not be dead-lettered: %+v", events)
+	}
+}
+
+func TestWithDeadLetterRateLimited(t *testing.T) {
+	dlq, capture := captureDeadLetters("dlq", nil)
+	sink := NewSink("limited", func(_ context.Context, _ Log) error { return nil }).
+		WithRateLimit(RateLimiterConfig{RequestsPerSecond: 0.001, BurstSize: 1}).
+		WithDeadLetter(dlq)
+
+	ctx := context.Background()
+	_, _ = sink.Process(ctx, NewEvent(INFO, "first", nil))  //nolint:errcheck // Test
+	_, _ = sink.Process(ctx, NewEvent(INFO, "second", nil)) //nolint:errcheck // Test
+
+	_, letters := capture.snapshot()
+	if len(letters) != 1 {
+		t.Fatalf("expected the rejected event to be dead-lettered, got %d", len(letters))
+	}
+	if letters[0].Attempts != 0 || !errors.Is(letters[0].Err, ErrRateLimited) || letters[0].FirstFailure.IsZero() {
+		t.Errorf("unexpected dead letter metadata: %+v", letters[0])
+	}
+}
+
+func TestSetDeadLetter(t *testing.T) {
+	global, capture := captureDeadLetters("global-dlq", errors.New("dlq down too"))
+	useDeadLetter(t, global)
+
+	failing := func(_ context.Context, _ Log) error { return errors.New("boom") }
+	own, ownCapture := captureDeadLetters("own-dlq", nil)
+
+	ctx := context.Background()
+	_, _ = NewSink("plain", failing).Process(ctx, NewEvent(ERROR, "plain", nil))                     //nolint:errcheck // Test
+	_, _ = NewSink("owned", failing).WithDeadLetter(own).Process(ctx, NewEvent(ERROR, "owned", nil)) //nolint:errcheck // Test
+
+	events, letters := capture.snapshot()
+	if len(events) != 1 || events[0].Message != "plain" {
+		t.Fatalf("global dead letter should only see the plain sink, and only once: %+v", events)
+	}
+	if letters[0].Sink != "plain" || letters[0].Attempts != 1 {
+		t.Errorf("unexpected dead letter metadata: %+v", letters[0])
+	}
+	if ownEvents, _ := ownCapture.snapshot(); len(ownEvents) != 1 {
+		t.Errorf("own dead letter should receive its sink's failure, got %d", len(ownEvents))
+	}
+}
+
+func TestSetDeadLetterAsync(t *testing.T) {
+	global, capture := captureDeadLetters("global-dlq", nil)
+	useDeadLetter(t, global)
+
+	sink := NewSink("background", func(_ context.Context, _ Log) error { return errors.New("boom") }).
+		WithRetry(2).
+		WithAsync()
+	_, _ = sink.Process(context.Background(), NewEvent(ERROR, "async", nil)) //nolint:errcheck // Test
+
+	if err := sink.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	_, letters := capture.snapshot()
+	if len(letters) != 1 || letters[0].Sink != "background" || letters[0].Attempts != 2 {
+		t.Errorf("unexpected dead letters: %+v", letters)
+	}
+}
+
+func TestDeadLetterFileReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead-letter.ndjson")
+	file := DeadLetterFile(path)
+
+	errDown := errors.New("service down")
+	sink := NewSink("api", func(_ context.Context, _ Log) error {
+		return errors.Join(errors.New("attempt failed"), errDown)
+	}).WithDeadLetter(file)
+
+	when := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	fields := []Field{
+		String("order", "42"),
+		Int("items", 3),
+		Int64("total_cents", 1999),
+		Float64("ratio", 0.5),
+		Bool("retry", true),
+		Duration("latency", 250*time.Millisecond),
+		Time("placed", when),
+		Strings("tags", []string{"a", "b"}),
+		Uint("attempt", 2),
+		Uint64("bytes", 1<<40),
+		Int32("partition", -3),
+		Float32("score", 0.25),
+		Ints("shards", []int{1, 2}),
+		Float64s("weights", []float64{0.5, 1.5}),
+		Bools("checks", []bool{true, false}),
+		Times("retried_at", []time.Time{when}),
+		Durations("phases", []time.Duration{time.Second}),
+		Group("customer", String("id", "c-7"), Group("address", String("country", "NZ"))),
+		Err(errDown),
+		NamedErr("cause", fmt.Errorf("charge: %w", errDown)),
+		Err(nil),
+	}
+	event := NewEvent(ERROR, "payment failed", fields)
+	_, _ = sink.Process(context.Background(), event) //nolint:errcheck // Test
+
+	if err := sink.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte(`"error_chain":["attempt failed\nservice down","attempt failed","service down"]`)) {
+		t.Errorf("error chain missing from record: %s", data)
+	}
+
+	var replayed []Log
+	var letters []DeadLetter
+	err = ReplayDeadLetters(bytes.NewReader(data), func(event Log, letter DeadLetter) error {
+		replayed = append(replayed, event)
+		letters = append(letters, letter)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replayed) != 1 {
+		t.Fatalf("expected 1 replayed event, got %d", len(replayed))
+	}
+
+	got := replayed[0]
+	if got.Signal != ERROR || got.Message != "payment failed" || !got.Time.Equal(event.Time) {
+		t.Errorf("unexpected replayed event: %+v", got)
+	}
+	for i, field := range got.Data {
+		want := fields[i]
+		if want.Type == TimeType {
+			if !field.Value.(time.Time).Equal(when) { //nolint:errcheck,forcetypeassert // Test
+				t.Errorf("time field = %v", field.Value)
+			}
+			continue
+		}
+		if want.Type == ErrorType && want.Value != nil {
+			// Replayed errors render like the originals
+			if !reflect.DeepEqual(jsonFieldValue(field), jsonFieldValue(want)) {
+				t.Errorf("error field %q = %+v, want %+v", want.Key, jsonFieldValue(field), jsonFieldValue(want))
+			}
+			continue
+		}
+		if !reflect.DeepEqual(field, want) {
+			t.Errorf("field %d = %#v, want %#v", i, field, want)
+		}
+	}
+
+	if letters[0].Sink != "api" || letters[0].Attempts != 1 || letters[0].Err == nil {
+		t.Errorf("unexpected replayed metadata: %+v", letters[0])
+	}
+}
+
+func TestDeadLetterFileMarshalers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead-letter.ndjson")
+	file := DeadLetterFile(path)
+
+	user := testUser{id: "u1", password: "hunter2", roles: testRoles{"admin"}, age: 36}
+	event := NewEvent(ERROR, "signup failed", []Field{Object("user", user), Array("roles", user.roles)})
+	if _, err := file.Process(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("hunter2")) {
+		t.Errorf("marshaled object leaked a hidden property: %s", data)
+	}
+
+	var replayed Log
+	err = ReplayDeadLetters(bytes.NewReader(data), func(event Log, _ DeadLetter) error {
+		replayed = event
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := renderJSON(t, replayed.Data...); got != renderJSON(t, event.Data...) {
+		t.Errorf("replayed fields render as %s, want %s", got, renderJSON(t, event.Data...))
+	}
+}
+
+func TestReplayDeadLettersInvalid(t *testing.T) {
+	err := ReplayDeadLetters(bytes.NewBufferString("{not json}\n"), func(Log, DeadLetter) error { return nil })
+	if err == nil {
+		t.Error("expected error for invalid line")
+	}
+}
+
+func TestDeadLetterTopology(t *testing.T) {
+	useTopologyLogger(t)
+
+	Hook(ERROR, noopSink("api").WithRetry(2).WithDeadLetter(noopSink("dlq")))
+
+	got := Routes()[ERROR][0]
+	if kinds := layerKinds(got); !reflect.DeepEqual(kinds, []string{"dead-letter", "retry"}) {
+		t.Fatalf("layers = %v", kinds)
+	}
+	if got.Layers[0].Fallback == nil || got.Layers[0].Fallback.Name != "dlq" {
+		t.Errorf("dead-letter branch = %+v", got.Layers[0].Fallback)
+	}
+}
+
+func TestDeadLetterFileNeverRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead-letter.ndjson")
+	writer, err := newAppendFileWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.close(context.Background()) //nolint:errcheck // Test
+
+	// Pretend the file is far beyond any rotation size
+	writer.currentSize = 1 << 40
+	for _, line := range []string{"first\n", "second\n"} {
+		if err := writer.write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Errorf("dead-letter file was rotated: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "first\nsecond\n" {
+		t.Errorf("file = %q", data)
+	}
+}
@@ -0,0 +1,189 @@
+package zlog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/zoobzio/pipz"
+)
+
+// ZLOG_INTERNAL is the signal for zlog's own diagnostics: sink failures,
+// dropped events, circuit-breaker trips and rate-limit rejections.
+// Enable it with SetInternalDiagnostics.
+const ZLOG_INTERNAL Signal = "ZLOG_INTERNAL"
+
+// Errors reported to the error handler for failures zlog itself detects.
+var (
+	// ErrRateLimited is reported when WithRateLimit rejects an event.
+	ErrRateLimited = errors.New("zlog: rate limit exceeded")
+
+	// ErrCircuitOpen is reported when WithCircuitBreaker rejects an event
+	// because the circuit is open.
+	ErrCircuitOpen = errors.New("zlog: circuit breaker open")
+
+	// ErrDropped is reported for events emitted after the logger was shut down.
+	ErrDropped = errors.New("zlog: event dropped after shutdown")
+)
+
+// loggerName is reported as the sink name for failures that happen before
+// any sink is involved, such as events dropped after Shutdown.
+const loggerName pipz.Name = "zlog"
+
+var (
+	errorHandler        atomic.Pointer[func(pipz.Name, Log, error)]
+	internalDiagnostics atomic.Bool
+)
+
+// internalKey marks contexts carrying ZLOG_INTERNAL events.
+type internalKey struct{}
+
+func init() {
+	RegisterSignal(ZLOG_INTERNAL, SignalInfo{
+		Severity:    SeverityWarn,
+		Description: "Diagnostics about zlog itself, such as failing sinks.",
+		Tags:        []string{"internal"},
+	})
+}
+
+// SetErrorHandler installs a function called whenever a sink fails to process
+// an event. Pass nil to remove it.
+//
+// Without a handler, sink errors are discarded - logging never fails the
+// application - which also means a broken sink can fail silently forever:
+//
+//	zlog.SetErrorHandler(func(sink pipz.Name, event zlog.Log, err error) {
+//	    sinkFailures.WithLabelValues(string(sink)).Inc()
+//	    if errors.Is(err, zlog.ErrCircuitOpen) {
+//	        pager.Notify("log sink " + string(sink) + " is down")
+//	    }
+//	})
+//
+// The handler receives the name given to NewSink, the event that failed and
+// the error. Rate-limit rejections, circuit-breaker trips and events dropped
+// after Shutdown are reported with ErrRateLimited, ErrCircuitOpen and
+// ErrDropped. The handler runs synchronously on the failing sink's goroutine,
+// so keep it fast.
+func SetErrorHandler(handler func(sinkName pipz.Name, event Log, err error)) {
+	if handler == nil {
+		errorHandler.Store(nil)
+		return
+	}
+	errorHandler.Store(&handler)
+}
+
+// SetInternalDiagnostics turns ZLOG_INTERNAL events on or off.
+//
+// When enabled, every failure passed to the error handler is also emitted
+// through the default logger as a ZLOG_INTERNAL event with sink, event_signal,
+// event_message and error fields, so it can be routed like any other signal:
+//
+//	zlog.SetInternalDiagnostics(true)
+//	zlog.Hook(zlog.ZLOG_INTERNAL, zlog.NewPrettyConsoleSink())
+//
+// ZLOG_INTERNAL has SeverityWarn, so EnableStandardLogging at WARN or below
+// prints it too. Failures while handling a ZLOG_INTERNAL event are passed to
+// the error handler but never emitted again, so a sink that fails on every
+// event cannot feed itself.
+func SetInternalDiagnostics(enabled bool) {
+	internalDiagnostics.Store(enabled)
+}
+
+// reportSinkError passes a sink failure to the error handler and, if enabled,
+// emits it as a ZLOG_INTERNAL event.
+func reportSinkError(ctx context.Context, sink pipz.Name, event Log, err error) {
+	if handler := errorHandler.Load(); handler != nil {
+		(*handler)(sink, event, err)
+	}
+
+	if !internalDiagnostics.Load() || event.Signal == ZLOG_INTERNAL || ctx.Value(internalKey{}) != nil {
+		return
+	}
+
+	internal := NewEvent(ZLOG_INTERNAL, describeSinkError(err), []Field{
+		String("sink", string(sink)),
+		String("event_signal", string(event.Signal)),
+		String("event_message", event.Message),
+		Err(err),
+	})
+	currentLogger().ProcessContext(context.WithValue(context.Background(), internalKey{}, true), internal)
+}
+
+// describeSinkError picks the message for a ZLOG_INTERNAL event.
+func describeSinkError(err error) string {
+	switch {
+	case errors.Is(err, ErrCircuitOpen):
+		return "Circuit breaker open"
+	case errors.Is(err, ErrRateLimited):
+		return "Rate limit exceeded"
+	case errors.Is(err, ErrDropped):
+		return "Event dropped"
+	default:
+		return "Sink failed"
+	}
+}
+
+// rateLimitGate applies a rate limiter before the next processor and marks
+// rejections with ErrRateLimited.
+type rateLimitGate struct {
+	limiter *pipz.RateLimiter[Log]
+	next    pipz.Chainable[Log]
+}
+
+// Process implements pipz.Chainable.
+func (g rateLimitGate) Process(ctx context.Context, event Log) (Log, error) {
+	if _, err := g.limiter.Process(ctx, event); err != nil {
+		return event, fmt.Errorf("%w: %w", ErrRateLimited, err)
+	}
+	return g.next.Process(ctx, event)
+}
+
+// Name implements pipz.Chainable.
+func (rateLimitGate) Name() pipz.Name {
+	return "rate-limited-sink"
+}
+
+// circuitCalledKey carries the flag set when a circuit breaker lets an event
+// through to the processor it protects.
+type circuitCalledKey struct{}
+
+// circuitGuard marks errors returned by an open circuit breaker with
+// ErrCircuitOpen. An error counts as a trip when the protected processor
+// was never called.
+type circuitGuard struct {
+	breaker *pipz.CircuitBreaker[Log]
+}
+
+// Process implements pipz.Chainable.
+func (g circuitGuard) Process(ctx context.Context, event Log) (Log, error) {
+	called := new(atomic.Bool)
+	result, err := g.breaker.Process(context.WithValue(ctx, circuitCalledKey{}, called), event)
+	if err != nil && !called.Load() {
+		err = fmt.Errorf("%w: %w", ErrCircuitOpen, err)
+	}
+	return result, err
+}
+
+// Name implements pipz.Chainable.
+func (g circuitGuard) Name() pipz.Name {
+	return g.breaker.Name()
+}
+
+// circuitProbe records that the circuit breaker called through.
+type circuitProbe struct {
+	next pipz.Chainable[Log]
+}
+
+// Process implements pipz.Chainable.
+func (p circuitProbe) Process(ctx context.Context, event Log) (Log, error) {
+	if called, ok := ctx.Value(circuitCalledKey{}).(*atomic.Bool); ok {
+		called.Store(true)
+	}
+	return p.next.Process(ctx, event)
+}
+
+// Name implements pipz.Chainable.
+func (p circuitProbe) Name() pipz.Name {
+	return p.next.Name()
+}
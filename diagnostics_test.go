@@ -0,0 +1,158 @@
+package zlog
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zoobzio/pipz"
+)
+
+// sinkFailure is one call to the error handler.
+type sinkFailure struct {
+	err    error
+	sink   pipz.Name
+	signal Signal
+}
+
+// captureErrors installs an error handler for the duration of a test.
+func captureErrors(t *testing.T) func() []sinkFailure {
+	t.Helper()
+
+	var mu sync.Mutex
+	var failures []sinkFailure
+	SetErrorHandler(func(sink pipz.Name, event Log, err error) {
+		mu.Lock()
+		failures = append(failures, sinkFailure{sink: sink, signal: event.Signal, err: err})
+		mu.Unlock()
+	})
+	t.Cleanup(func() { SetErrorHandler(nil) })
+
+	return func() []sinkFailure {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]sinkFailure(nil), failures...)
+	}
+}
+
+func TestSetErrorHandler(t *testing.T) {
+	failures := captureErrors(t)
+	errBroken := errors.New("connection refused")
+
+	logger := New()
+	broken := NewSink("http", func(_ context.Context, _ Log) error { return errBroken })
+	logger.Hook(INFO, broken.WithRetry(2))
+	logger.Hook(WARN, broken.WithAsync())
+	logger.Info("sync failure")
+	logger.Warn("async failure")
+
+	if err := logger.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+	logger.Info("after shutdown")
+
+	got := failures()
+	if len(got) != 3 {
+		t.Fatalf("expected 3 failures, got %+v", got)
+	}
+	for _, failure := range got[:2] {
+		if failure.sink != "http" || !errors.Is(failure.err, errBroken) {
+			t.Errorf("unexpected failure: %+v", failure)
+		}
+	}
+	if got[2].sink != loggerName || !errors.Is(got[2].err, ErrDropped) {
+		t.Errorf("expected drop after shutdown, got %+v", got[2])
+	}
+}
+
+func TestRateLimitAndCircuitErrors(t *testing.T) {
+	failures := captureErrors(t)
+
+	limited := NewSink("limited", func(_ context.Context, _ Log) error { return nil }).
+		WithRateLimit(RateLimiterConfig{RequestsPerSecond: 0.001, BurstSize: 1})
+	breaker := NewSink("breaker", func(_ context.Context, _ Log) error { return errors.New("down") }).
+		WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, ResetTimeout: time.Hour})
+
+	ctx := context.Background()
+	event := NewEvent(INFO, "msg", nil)
+	for i := 0; i < 2; i++ {
+		_, _ = limited.Process(ctx, event) //nolint:errcheck // Failures checked through the handler
+		_, _ = breaker.Process(ctx, event) //nolint:errcheck // Failures checked through the handler
+	}
+
+	got := failures()
+	if len(got) != 3 {
+		t.Fatalf("expected 3 failures, got %+v", got)
+	}
+	if got[0].sink != "breaker" || errors.Is(got[0].err, ErrCircuitOpen) {
+		t.Errorf("first breaker failure should be the sink error: %+v", got[0])
+	}
+	if got[1].sink != "limited" || !errors.Is(got[1].err, ErrRateLimited) {
+		t.Errorf("expected rate limit rejection: %+v", got[1])
+	}
+	if got[2].sink != "breaker" || !errors.Is(got[2].err, ErrCircuitOpen) {
+		t.Errorf("expected circuit breaker trip: %+v", got[2])
+	}
+}
+
+func TestInternalDiagnostics(t *testing.T) {
+	original := defaultLogger
+	defaultLogger = NewLogger[Fields]()
+	SetInternalDiagnostics(true)
+	t.Cleanup(func() {
+		defaultLogger = original
+		SetInternalDiagnostics(false)
+	})
+	failures := captureErrors(t)
+
+	// A sink that fails on everything, including the diagnostics about itself
+	var mu sync.Mutex
+	var seen []Log
+	broken := NewSink("broken", func(_ context.Context, event Log) error {
+		mu.Lock()
+		seen = append(seen, event)
+		mu.Unlock()
+		return errors.New("always fails")
+	})
+	HookAll(broken)
+
+	Info("trigger")
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(seen) != 2 {
+		t.Fatalf("expected original and one internal event, got %d", len(seen))
+	}
+	internal := seen[1]
+	if internal.Signal != ZLOG_INTERNAL || internal.Message != "Sink failed" {
+		t.Errorf("unexpected internal event: %s %q", internal.Signal, internal.Message)
+	}
+
+	fields := map[string]any{}
+	for _, field := range internal.Data {
+		fields[field.Key] = field.Value
+	}
+	if fields["sink"] != "broken" || fields["event_signal"] != "INFO" || fields["event_message"] != "trigger" {
+		t.Errorf("unexpected internal fields: %v", fields)
+	}
+
+	// Both failures reach the handler, but only the first is re-emitted
+	if len(failures()) != 2 {
+		t.Errorf("expected 2 handler calls, got %d", len(failures()))
+	}
+}
+
+func TestSinkNameSurvivesAdapters(t *testing.T) {
+	sink := NewSink("audit", func(_ context.Context, _ Log) error { return nil }).
+		WithRetry(3).
+		WithTimeout(time.Second).
+		WithFilter(func(_ context.Context, _ Log) bool { return true }).
+		WithAsync()
+
+	if sink.Name() != "audit" {
+		t.Errorf("Name() = %s, want audit", sink.Name())
+	}
+}
@@ -1,14 +1,60 @@
 package zlog
 
+import (
+	"sync"
+
+	"github.com/zoobzio/pipz"
+)
+
 // Package-level private logger for the global logging system.
 // This replaces the old Dispatch struct with a Logger[Fields] instance.
-var defaultLogger *Logger[Fields]
+// The package functions delegate to it; SetDefault swaps it out.
+var (
+	defaultLogger *Logger[Fields]
+	defaultMu     sync.RWMutex
+)
 
 // Initialize the default logger.
 func init() {
 	defaultLogger = NewLogger[Fields]()
 }
 
+// currentLogger returns the logger the package functions delegate to.
+func currentLogger() *Logger[Fields] {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultLogger
+}
+
+// SetDefault makes l the logger used by the package-level functions.
+//
+// Events emitted through zlog.Emit, zlog.Info and friends, and sinks added
+// with zlog.Hook, go to l from then on. The previous default keeps its routes
+// and can still be used through any FieldsLogger that refers to it.
+//
+//	logger := zlog.New()
+//	logger.Hook(zlog.INFO, consoleSink)
+//	zlog.SetDefault(logger)
+//
+// Passing nil is a no-op.
+func SetDefault(l *FieldsLogger) {
+	if l == nil || l.Logger == nil {
+		return
+	}
+	defaultMu.Lock()
+	defaultLogger = l.Logger
+	defaultMu.Unlock()
+}
+
+// Default returns the logger used by the package-level functions.
+//
+//	previous := zlog.Default()
+//	zlog.SetDefault(zlog.New())
+//	defer zlog.SetDefault(previous)
+func Default() *FieldsLogger {
+	return &FieldsLogger{Logger: currentLogger()}
+}
+
 // Hook registers one or more sinks to process events with the specified signal.
 //
 // Multiple sinks can process the same signal - they run in parallel using
@@ -26,13 +72,83 @@ func init() {
 //	// Hook business events
 //	zlog.Hook(PAYMENT_RECEIVED, auditSink, analyticsSink)
 //
-// Routes can be added at any time, even after events start flowing.
-// There's no way to remove routes - design your signal strategy accordingly.
-func Hook(signal Signal, sinks ...*Sink) {
+// Routes can be added at any time, even after events start flowing. The
+// returned Registration removes exactly the sinks added by this call:
+//
+//	reg := zlog.Hook(zlog.DEBUG, verboseSink)
+//	defer reg.Remove()
+//
+// Sinks can also be removed with Unhook, UnhookAll or ReplaceHooks.
+func Hook(signal Signal, sinks ...*Sink) *Registration {
 	// Convert sinks to processors for the typed logger
-	for _, sink := range sinks {
-		defaultLogger.Hook(signal, *sink)
+	return currentLogger().Register(signal, sinkChainables(sinks)...)
+}
+
+// HookPattern registers sinks for every signal matching a glob pattern,
+// where '*' matches any run of characters:
+//
+//	// Instead of hooking PAYMENT_RECEIVED, PAYMENT_REFUNDED, PAYMENT_FAILED...
+//	zlog.HookPattern("PAYMENT_*", auditSink)
+//
+// Pattern sinks run alongside exact sinks for the same signal. Matching
+// happens once per distinct signal and is cached, so routing stays a map
+// lookup on the hot path.
+func HookPattern(pattern string, sinks ...*Sink) *Registration {
+	return HookMatch(MatchGlob(pattern), sinks...)
+}
+
+// HookMatch registers sinks for every signal accepted by the matcher.
+//
+//	zlog.HookMatch(zlog.MatchRegexp(regexp.MustCompile(`^(AUTH|SESSION)_`)), securitySink)
+//	zlog.HookMatch(zlog.MatchPrefix("DEBUG_"), debugSink)
+func HookMatch(matcher SignalMatcher, sinks ...*Sink) *Registration {
+	return currentLogger().RegisterMatch(matcher, sinkChainables(sinks)...)
+}
+
+// HookUnmatched registers sinks for events whose signal has no exact or
+// pattern route. Use it as a catch-all to spot signals nobody handles:
+//
+//	zlog.HookUnmatched(zlog.NewSink("unrouted", reportUnroutedSignal))
+func HookUnmatched(sinks ...*Sink) *Registration {
+	return currentLogger().RegisterUnmatched(sinkChainables(sinks)...)
+}
+
+// sinkChainables converts sinks into processors for the typed logger.
+// Sinks are registered by pointer so they can later be matched by identity.
+func sinkChainables(sinks []*Sink) []pipz.Chainable[Log] {
+	chainables := make([]pipz.Chainable[Log], len(sinks))
+	for i, sink := range sinks {
+		chainables[i] = sink
 	}
+	return chainables
+}
+
+// Unhook removes a sink from the specified signal.
+//
+// The sink must be the same *Sink that was passed to Hook. When the last sink
+// for a signal is removed, the signal is no longer routed anywhere. Unhook
+// reports whether the sink was hooked to the signal.
+//
+//	zlog.Unhook(zlog.DEBUG, verboseSink)
+func Unhook(signal Signal, sink *Sink) bool {
+	return currentLogger().Unhook(signal, sink)
+}
+
+// UnhookAll removes a sink from every signal it is hooked to, including
+// registrations made with HookAll. It reports whether anything was removed.
+//
+//	zlog.UnhookAll(legacySink)
+func UnhookAll(sink *Sink) bool {
+	return currentLogger().UnhookAll(sink)
+}
+
+// ReplaceHooks atomically replaces all sinks for a signal with the given set.
+// Calling it with no sinks removes the route for the signal.
+//
+//	// Feature flag enabled - send payments to the new pipeline only
+//	zlog.ReplaceHooks(PAYMENT_RECEIVED, newAuditSink, analyticsSink)
+func ReplaceHooks(signal Signal, sinks ...*Sink) {
+	currentLogger().ReplaceHooks(signal, sinkChainables(sinks)...)
 }
 
 // RouteSignal is a backward-compatible alias for Hook.
@@ -58,10 +174,10 @@ func RouteSignal(signal Signal, sinks ...*Sink) {
 //
 // Global sinks run in the order they were registered, before any signal-specific
 // routing occurs. They see every event emitted to the system.
-func HookAll(sinks ...*Sink) {
-	for _, sink := range sinks {
-		defaultLogger.HookAll(*sink)
-	}
+//
+// The returned Registration removes exactly the sinks added by this call.
+func HookAll(sinks ...*Sink) *Registration {
+	return currentLogger().RegisterAll(sinkChainables(sinks)...)
 }
 
 // RouteAll is a backward-compatible alias for HookAll.
@@ -3,6 +3,7 @@ package zlog
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 )
 
@@ -32,3 +33,98 @@ func TestDispatchProcessError(t *testing.T) {
 
 	// Test passes if no panic occurred
 }
+
+func TestHookRegistrationRemove(t *testing.T) {
+	defer func() { defaultLogger = NewLogger[Fields]() }()
+
+	var count int64
+	sink := NewSink("registration-test", func(_ context.Context, _ Log) error {
+		atomic.AddInt64(&count, 1)
+		return nil
+	})
+
+	signal := Signal("TEST_REGISTRATION")
+	reg := Hook(signal, sink)
+	Emit(signal, "hooked")
+	reg.Remove()
+	Emit(signal, "unhooked")
+
+	if got := atomic.LoadInt64(&count); got != 1 {
+		t.Errorf("expected 1 event, got %d", got)
+	}
+}
+
+func TestUnhook(t *testing.T) {
+	defer func() { defaultLogger = NewLogger[Fields]() }()
+
+	var count int64
+	sink := NewSink("unhook-test", func(_ context.Context, _ Log) error {
+		atomic.AddInt64(&count, 1)
+		return nil
+	})
+
+	signal := Signal("TEST_UNHOOK")
+	Hook(signal, sink)
+
+	if !Unhook(signal, sink) {
+		t.Fatal("expected Unhook to remove the sink")
+	}
+	if Unhook(signal, sink) {
+		t.Error("expected second Unhook to report nothing removed")
+	}
+
+	Emit(signal, "unhooked")
+	if got := atomic.LoadInt64(&count); got != 0 {
+		t.Errorf("expected no events, got %d", got)
+	}
+}
+
+func TestUnhookAll(t *testing.T) {
+	defer func() { defaultLogger = NewLogger[Fields]() }()
+
+	var count int64
+	sink := NewSink("unhook-all-test", func(_ context.Context, _ Log) error {
+		atomic.AddInt64(&count, 1)
+		return nil
+	})
+
+	Hook("TEST_UNHOOK_ALL_A", sink)
+	Hook("TEST_UNHOOK_ALL_B", sink)
+	HookAll(sink)
+
+	if !UnhookAll(sink) {
+		t.Fatal("expected UnhookAll to remove the sink")
+	}
+
+	Emit("TEST_UNHOOK_ALL_A", "a")
+	Emit("TEST_UNHOOK_ALL_B", "b")
+	if got := atomic.LoadInt64(&count); got != 0 {
+		t.Errorf("expected no events, got %d", got)
+	}
+}
+
+func TestReplaceHooks(t *testing.T) {
+	defer func() { defaultLogger = NewLogger[Fields]() }()
+
+	var oldCount, newCount int64
+	oldSink := NewSink("old", func(_ context.Context, _ Log) error {
+		atomic.AddInt64(&oldCount, 1)
+		return nil
+	})
+	newSink := NewSink("new", func(_ context.Context, _ Log) error {
+		atomic.AddInt64(&newCount, 1)
+		return nil
+	})
+
+	signal := Signal("TEST_REPLACE")
+	Hook(signal, oldSink)
+	ReplaceHooks(signal, newSink)
+	Emit(signal, "replaced")
+
+	if atomic.LoadInt64(&oldCount) != 0 {
+		t.Error("expected old sink to receive no events")
+	}
+	if atomic.LoadInt64(&newCount) != 1 {
+		t.Errorf("expected new sink to receive 1 event, got %d", atomic.LoadInt64(&newCount))
+	}
+}
@@ -0,0 +1,171 @@
+package zlog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Encoder formats events for the built-in sinks that write bytes. Choosing
+// an encoder is independent of choosing a destination:
+//
+//	zlog.NewWriterSink(os.Stdout, zlog.LogfmtEncoder{})
+//	zlog.NewRotatingFileSink("app.log", 0, 0, zlog.WithEncoder(zlog.TextEncoder{}))
+//	zlog.NewHTTPSink(url, zlog.WithEncoder(zlog.JSONEncoder{}))
+//
+// Encode appends one complete entry for the event to buf, ending with a
+// newline. Sinks reuse buffers, so an encoder must not keep buf after it
+// returns. Encoders are called concurrently and must be safe for that.
+type Encoder interface {
+	Encode(event Log, buf *Buffer) error
+}
+
+// Buffer is the byte buffer an Encoder appends an entry to. It implements
+// io.Writer, so fmt.Fprintf and friends can write to it.
+type Buffer struct {
+	bytes []byte
+}
+
+// Write appends p to the buffer. It never fails.
+func (b *Buffer) Write(p []byte) (int, error) {
+	b.bytes = append(b.bytes, p...)
+	return len(p), nil
+}
+
+// WriteString appends s to the buffer. It never fails.
+func (b *Buffer) WriteString(s string) (int, error) {
+	b.bytes = append(b.bytes, s...)
+	return len(s), nil
+}
+
+// WriteByte appends c to the buffer. It never fails.
+func (b *Buffer) WriteByte(c byte) error {
+	b.bytes = append(b.bytes, c)
+	return nil
+}
+
+// Bytes returns the buffer contents. The slice is only valid until the
+// buffer is next written to or reset.
+func (b *Buffer) Bytes() []byte {
+	return b.bytes
+}
+
+// String returns the buffer contents as a string.
+func (b *Buffer) String() string {
+	return string(b.bytes)
+}
+
+// Len returns the number of bytes in the buffer.
+func (b *Buffer) Len() int {
+	return len(b.bytes)
+}
+
+// Reset empties the buffer, keeping its capacity.
+func (b *Buffer) Reset() {
+	b.bytes = b.bytes[:0]
+}
+
+// maxPooledBuffer is the largest buffer returned to the pool, so one huge
+// event does not pin its memory for good.
+const maxPooledBuffer = 64 * 1024
+
+// bufferPool holds buffers for sinks to encode into.
+var bufferPool = sync.Pool{
+	New: func() any {
+		return &Buffer{bytes: make([]byte, 0, 1024)}
+	},
+}
+
+// getBuffer takes an empty buffer from the pool.
+func getBuffer() *Buffer {
+	buf := bufferPool.Get().(*Buffer) //nolint:errcheck // Only *Buffer values are pooled
+	buf.Reset()
+	return buf
+}
+
+// putBuffer returns a buffer to the pool.
+func putBuffer(buf *Buffer) {
+	if cap(buf.bytes) > maxPooledBuffer {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+// EncoderOption sets the Encoder of a built-in sink. Create it with
+// WithEncoder.
+type EncoderOption struct {
+	encoder Encoder
+}
+
+// WithEncoder makes a sink format events with enc instead of its default
+// format. It is accepted by NewRotatingFileSink, NewHTTPSink,
+// NewPrettyConsoleSink and ConsoleJSONSink:
+//
+//	zlog.NewRotatingFileSink("app.log", 0, 0, zlog.WithEncoder(zlog.LogfmtEncoder{}))
+//	zlog.NewPrettyConsoleSink(zlog.WithEncoder(zlog.TextEncoder{}))
+//
+// A nil encoder leaves the default in place.
+func WithEncoder(enc Encoder) EncoderOption {
+	return EncoderOption{encoder: enc}
+}
+
+// applyHTTP implements HTTPOption.
+func (o EncoderOption) applyHTTP(config *httpConfig) {
+	if o.encoder != nil {
+		config.encoder = o.encoder
+	}
+}
+
+// applyFile implements FileOption.
+func (o EncoderOption) applyFile(config *fileConfig) {
+	if o.encoder != nil {
+		config.encoder = o.encoder
+	}
+}
+
+// applyConsole implements ConsoleOption.
+func (o EncoderOption) applyConsole(config *consoleConfig) {
+	if o.encoder != nil {
+		config.encoder = o.encoder
+	}
+}
+
+// NewWriterSink creates a sink that writes events to w, formatted by enc:
+//
+//	zlog.HookAll(zlog.NewWriterSink(os.Stdout, zlog.LogfmtEncoder{}))
+//
+// Each event is written with a single Write call, and writes are
+// serialized, so w does not need to be safe for concurrent use. The sink
+// does not close w.
+func NewWriterSink(w io.Writer, enc Encoder) *Sink {
+	return newWriterSink("writer", w, enc)
+}
+
+// newWriterSink creates a writer sink with the given name.
+func newWriterSink(name string, w io.Writer, enc Encoder) *Sink {
+	var mu sync.Mutex
+	return NewSink(name, func(_ context.Context, event Log) error {
+		buf := getBuffer()
+		defer putBuffer(buf)
+
+		if err := enc.Encode(event, buf); err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		_, err := w.Write(buf.Bytes())
+		return err
+	})
+}
+
+// stderrWriter writes to whatever os.Stderr is at the time of the write, so
+// sinks created at init follow later reassignments of os.Stderr.
+type stderrWriter struct{}
+
+// Write implements io.Writer.
+func (stderrWriter) Write(p []byte) (int, error) {
+	return os.Stderr.Write(p)
+}
@@ -0,0 +1,215 @@
+package zlog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testEvent returns an event with a fixed time and caller.
+func testEvent(message string, fields ...Field) Log {
+	event := NewEvent(INFO, message, fields)
+	event.Time = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	event.Caller = CallerInfo{File: "auth.go", Line: 42}
+	return event
+}
+
+func TestBuffer(t *testing.T) {
+	buf := &Buffer{}
+	buf.WriteString("a")
+	buf.WriteByte('b')
+	fmt.Fprintf(buf, "%d", 3)
+	if buf.String() != "ab3" || buf.Len() != 3 || !bytes.Equal(buf.Bytes(), []byte("ab3")) {
+		t.Errorf("buffer = %q", buf.String())
+	}
+
+	buf.Reset()
+	if buf.Len() != 0 {
+		t.Errorf("reset buffer has %d bytes", buf.Len())
+	}
+}
+
+func TestBufferPool(t *testing.T) {
+	buf := getBuffer()
+	buf.WriteString("leftover")
+	putBuffer(buf)
+
+	if again := getBuffer(); again.Len() != 0 {
+		t.Errorf("pooled buffer not reset: %q", again.String())
+	}
+
+	// Oversized buffers are dropped rather than pooled
+	huge := &Buffer{bytes: make([]byte, 0, maxPooledBuffer+1)}
+	putBuffer(huge)
+}
+
+// countingWriter is a bytes.Buffer that counts the writes it receives.
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+func TestNewWriterSink(t *testing.T) {
+	var out countingWriter
+	sink := NewWriterSink(&out, LogfmtEncoder{})
+	if sink.Name() != "writer" {
+		t.Errorf("name = %s", sink.Name())
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _ = sink.Process(context.Background(), testEvent("concurrent", Int("i", i))) //nolint:errcheck // Test
+		}(i)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 20 || out.writes != 20 {
+		t.Fatalf("expected 20 lines in 20 writes, got %d lines in %d writes", len(lines), out.writes)
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "time=2024-03-01T12:00:00Z signal=INFO message=concurrent") {
+			t.Errorf("interleaved or malformed line: %q", line)
+		}
+	}
+}
+
+type failingEncoder struct{}
+
+func (failingEncoder) Encode(Log, *Buffer) error {
+	return errors.New("unsupported")
+}
+
+func TestNewWriterSinkEncodeError(t *testing.T) {
+	var out bytes.Buffer
+	_, err := NewWriterSink(&out, failingEncoder{}).Process(context.Background(), testEvent("x"))
+	if err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Errorf("expected encoder error, got %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("nothing should be written on error, got %q", out.String())
+	}
+}
+
+func TestWithEncoderFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	sink := NewRotatingFileSink(path, 0, 0, WithEncoder(TextEncoder{}))
+	if _, err := sink.Process(context.Background(), testEvent("started", Int("workers", 4))); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "2024-03-01T12:00:00Z INFO started (auth.go:42) workers=4\n"; string(data) != want {
+		t.Errorf("got %q, want %q", data, want)
+	}
+}
+
+func TestWithEncoderHTTP(t *testing.T) {
+	var body, contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data := new(bytes.Buffer)
+		_, _ = data.ReadFrom(r.Body) //nolint:errcheck // Test
+		body, contentType = data.String(), r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink := NewHTTPSink(server.URL, WithEncoder(LogfmtEncoder{}))
+	if _, err := sink.Process(context.Background(), testEvent("sent")); err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "text/plain; charset=utf-8" {
+		t.Errorf("Content-Type = %s", contentType)
+	}
+	if want := "time=2024-03-01T12:00:00Z signal=INFO message=sent caller=auth.go:42\n"; body != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+
+	// Custom headers win over the encoder's Content-Type
+	sink = NewHTTPSink(server.URL, WithEncoder(PrettyEncoder{}), WithHeaders(map[string]string{"Content-Type": "text/x-custom"}))
+	if _, err := sink.Process(context.Background(), testEvent("sent")); err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "text/x-custom" {
+		t.Errorf("Content-Type = %s, want the custom header", contentType)
+	}
+}
+
+func TestWithEncoderConsole(t *testing.T) {
+	old := os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stderr = w
+	defer func() { os.Stderr = old }()
+
+	sink := NewPrettyConsoleSink(WithEncoder(JSONEncoder{}))
+	_, err = sink.Process(context.Background(), testEvent("hello"))
+	w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	_, _ = out.ReadFrom(r) //nolint:errcheck // Test
+	want := `{"time":"2024-03-01T12:00:00Z","signal":"INFO","message":"hello","caller":"auth.go:42"}` + "\n"
+	if out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+}
+
+func TestWithEncoderConsoleJSONSink(t *testing.T) {
+	old := os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stderr = w
+	defer func() { os.Stderr = old }()
+
+	sink := ConsoleJSONSink(false, WithEncoder(LogfmtEncoder{}))
+	_, err = sink.Process(context.Background(), testEvent("hello"))
+	w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	_, _ = out.ReadFrom(r) //nolint:errcheck // Test
+	want := "time=2024-03-01T12:00:00Z signal=INFO message=hello caller=auth.go:42\n"
+	if out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+}
+
+func TestWithEncoderNil(t *testing.T) {
+	config := &fileConfig{encoder: JSONEncoder{}}
+	WithEncoder(nil).applyFile(config)
+	if _, ok := config.encoder.(JSONEncoder); !ok {
+		t.Errorf("nil encoder replaced the default: %T", config.encoder)
+	}
+}
@@ -0,0 +1,143 @@
+package zlog
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// LogFielder is implemented by errors that carry structured context. JSON
+// sinks render the fields with the error:
+//
+//	type QueryError struct {
+//	    Table string
+//	    Err   error
+//	}
+//
+//	func (e *QueryError) LogFields() []zlog.Field {
+//	    return []zlog.Field{zlog.String("table", e.Table)}
+//	}
+//
+// Fields from every error in the chain are included; where keys repeat, the
+// outermost error wins.
+type LogFielder interface {
+	LogFields() []Field
+}
+
+// errorRecord is the JSON form of an error field:
+//
+//	{"message":"load config: open app.yaml: no such file or directory",
+//	 "type":"*fmt.wrapError",
+//	 "chain":["open app.yaml: no such file or directory","no such file or directory"]}
+//
+// Chain lists the wrapped causes, outermost first. An error joined with
+// errors.Join has no chain; its errors are rendered as an array instead.
+// Errors that carry a stack trace, like those of github.com/pkg/errors, add
+// it under "stack".
+type errorRecord struct {
+	Fields  map[string]any `json:"fields,omitempty"`
+	Message string         `json:"message"`
+	Type    string         `json:"type"`
+	Chain   []string       `json:"chain,omitempty"`
+	Errors  []errorRecord  `json:"errors,omitempty"`
+	Stack   StackTrace     `json:"stack,omitempty"`
+}
+
+// newErrorRecord builds the JSON form of err.
+func newErrorRecord(err error) errorRecord {
+	if replayed, ok := err.(*replayedError); ok { //nolint:errorlint // Only the error itself, not a wrapper
+		return replayed.record
+	}
+
+	record := errorRecord{
+		Message: err.Error(),
+		Type:    fmt.Sprintf("%T", err),
+	}
+
+	parent := record.Message
+	for current := err; current != nil; {
+		if fielder, ok := current.(LogFielder); ok { //nolint:errorlint // Each error in the chain is checked
+			record.addFields(fielder.LogFields())
+		}
+		// The innermost stack is closest to where the error was created
+		if stack := errorStack(current); len(stack) > 0 {
+			record.Stack = stack
+		}
+
+		switch wrapped := current.(type) { //nolint:errorlint // Walking the chain by hand
+		case interface{ Unwrap() error }:
+			current = wrapped.Unwrap()
+			// Wrappers that add nothing to the message are left out
+			if current != nil && current.Error() != parent {
+				parent = current.Error()
+				record.Chain = append(record.Chain, parent)
+			}
+		case interface{ Unwrap() []error }:
+			for _, inner := range wrapped.Unwrap() {
+				if inner != nil {
+					record.Errors = append(record.Errors, newErrorRecord(inner))
+				}
+			}
+			current = nil
+		default:
+			current = nil
+		}
+	}
+	return record
+}
+
+// errorStack returns the stack trace an error carries, if any. Errors can
+// provide one with a StackTrace method returning either a StackTrace or a
+// slice of program counters from runtime.Callers. The latter is what
+// github.com/pkg/errors returns; its Frame type is a uintptr, which is why
+// the method is found by reflection rather than an interface.
+func errorStack(err error) StackTrace {
+	if tracer, ok := err.(interface{ StackTrace() StackTrace }); ok { //nolint:errorlint // Each error in the chain is checked
+		return tracer.StackTrace()
+	}
+
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() {
+		return nil
+	}
+	signature := method.Type()
+	if signature.NumIn() != 0 || signature.NumOut() != 1 ||
+		signature.Out(0).Kind() != reflect.Slice || signature.Out(0).Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+
+	frames := method.Call(nil)[0]
+	if frames.Len() == 0 {
+		return nil
+	}
+	pcs := make([]uintptr, frames.Len())
+	for i := range pcs {
+		pcs[i] = uintptr(frames.Index(i).Uint())
+	}
+	return stackFromPCs(pcs)
+}
+
+// addFields adds fields the record does not have yet.
+func (r *errorRecord) addFields(fields []Field) {
+	if len(fields) == 0 {
+		return
+	}
+	if r.Fields == nil {
+		r.Fields = make(map[string]any, len(fields))
+	}
+	for _, field := range fields {
+		if _, exists := r.Fields[field.Key]; !exists {
+			r.Fields[field.Key] = jsonFieldValue(field.Resolve())
+		}
+	}
+}
+
+// replayedError stands in for an error restored from a dead-letter file. It
+// renders as the original error did.
+type replayedError struct {
+	record errorRecord
+}
+
+// Error returns the original error message.
+func (e *replayedError) Error() string {
+	return e.record.Message
+}
@@ -0,0 +1,170 @@
+package zlog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+type queryError struct {
+	err   error
+	table string
+}
+
+func (e *queryError) Error() string { return "query " + e.table + ": " + e.err.Error() }
+
+func (e *queryError) Unwrap() error { return e.err }
+
+func (e *queryError) LogFields() []Field {
+	return []Field{String("table", e.table), Int("attempt", 1)}
+}
+
+// renderError returns the JSON a sink writes for an error field.
+func renderError(t *testing.T, err error) string {
+	t.Helper()
+	entry := map[string]interface{}{}
+	addJSONFields(entry, []Field{Err(err)})
+	data, marshalErr := json.Marshal(entry["error"])
+	if marshalErr != nil {
+		t.Fatal(marshalErr)
+	}
+	return string(data)
+}
+
+func TestErrorRecordChain(t *testing.T) {
+	_, openErr := os.Open("/does/not/exist")
+	err := fmt.Errorf("load config: %w", openErr)
+
+	want := `{"message":"load config: open /does/not/exist: no such file or directory",` +
+		`"type":"*fmt.wrapError",` +
+		`"chain":["open /does/not/exist: no such file or directory","no such file or directory"]}`
+	if got := renderError(t, err); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	// The original error is still there for sinks that want it
+	var pathErr *fs.PathError
+	if value, ok := Err(err).Value.(error); !ok || !errors.As(value, &pathErr) {
+		t.Error("expected errors.As to find the *fs.PathError")
+	}
+}
+
+func TestErrorRecordSkipsRepeatedMessages(t *testing.T) {
+	cause := errors.New("timeout")
+	err := fmt.Errorf("%w", cause)
+
+	if got, want := renderError(t, err), `{"message":"timeout","type":"*fmt.wrapError"}`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestErrorRecordLogFields(t *testing.T) {
+	inner := &queryError{table: "orders", err: errors.New("deadlock")}
+	outer := &queryError{table: "payments", err: fmt.Errorf("retry: %w", inner)}
+
+	record := newErrorRecord(outer)
+	if record.Fields["table"] != "payments" {
+		t.Errorf("outermost error should win, got table=%v", record.Fields["table"])
+	}
+	if record.Fields["attempt"] != 1 {
+		t.Errorf("attempt = %v, want 1", record.Fields["attempt"])
+	}
+	if record.Type != "*zlog.queryError" {
+		t.Errorf("type = %s", record.Type)
+	}
+	if len(record.Chain) != 3 {
+		t.Errorf("chain = %q", record.Chain)
+	}
+}
+
+func TestErrorRecordJoin(t *testing.T) {
+	err := fmt.Errorf("shutdown: %w", errors.Join(errors.New("db: close failed"), nil, &queryError{table: "jobs", err: errors.New("busy")}))
+
+	want := `{"message":"shutdown: db: close failed\nquery jobs: busy",` +
+		`"type":"*fmt.wrapError",` +
+		`"chain":["db: close failed\nquery jobs: busy"],` +
+		`"errors":[{"message":"db: close failed","type":"*errors.errorString"},` +
+		`{"message":"query jobs: busy","type":"*zlog.queryError","chain":["busy"],"fields":{"attempt":1,"table":"jobs"}}]}`
+	if got := renderError(t, err); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestErrorRecordNil(t *testing.T) {
+	entry := map[string]interface{}{}
+	addJSONFields(entry, []Field{Err(nil)})
+	if entry["error"] != nil {
+		t.Errorf("nil error rendered as %v", entry["error"])
+	}
+}
+
+// pcFrame and pcStack mirror github.com/pkg/errors' Frame and StackTrace.
+type pcFrame uintptr
+
+type pcStack []pcFrame
+
+// stackError records its stack the way github.com/pkg/errors does.
+type stackError struct {
+	msg   string
+	stack pcStack
+}
+
+func newStackError(msg string) *stackError {
+	var pcs [32]uintptr
+	n := runtime.Callers(2, pcs[:])
+	stack := make(pcStack, n)
+	for i, pc := range pcs[:n] {
+		stack[i] = pcFrame(pc)
+	}
+	return &stackError{msg: msg, stack: stack}
+}
+
+func (e *stackError) Error() string { return e.msg }
+
+func (e *stackError) StackTrace() pcStack { return e.stack }
+
+func TestErrorRecordStack(t *testing.T) {
+	err := fmt.Errorf("charge: %w", newStackError("card declined"))
+
+	record := newErrorRecord(err)
+	if len(record.Stack) == 0 {
+		t.Fatal("expected the wrapped error's stack trace")
+	}
+	if !strings.HasSuffix(record.Stack[0].Function, "TestErrorRecordStack") {
+		t.Errorf("innermost frame = %+v, want the function that created the error", record.Stack[0])
+	}
+
+	var rendered struct {
+		Stack []Frame `json:"stack"`
+	}
+	if jsonErr := json.Unmarshal([]byte(renderError(t, err)), &rendered); jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+	if len(rendered.Stack) != len(record.Stack) || rendered.Stack[0].Line == 0 {
+		t.Errorf("rendered stack = %+v", rendered.Stack)
+	}
+}
+
+type tracedError struct{}
+
+func (tracedError) Error() string { return "traced" }
+
+func (tracedError) StackTrace() StackTrace {
+	return StackTrace{{Function: "app.handle", File: "handler.go", Line: 7}}
+}
+
+func TestErrorRecordStackTrace(t *testing.T) {
+	want := `{"message":"traced","type":"zlog.tracedError","stack":[{"function":"app.handle","file":"handler.go","line":7}]}`
+	if got := renderError(t, tracedError{}); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	if stack := newErrorRecord(errors.New("plain")).Stack; stack != nil {
+		t.Errorf("plain errors should have no stack, got %+v", stack)
+	}
+}
@@ -5,10 +5,14 @@ import (
 )
 
 // CallerInfo contains the file, line, and function of the log call site.
+//
+// With CallerLazy only the program counter is recorded; call Resolve to
+// fill in the remaining fields.
 type CallerInfo struct {
 	File     string
 	Function string
 	Line     int
+	pc       uintptr
 }
 
 // Event represents an immutable signal event that flows through sinks.
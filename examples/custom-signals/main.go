@@ -131,12 +131,8 @@ func simulateUserJourney(userID string) {
 	sessionCtx := context.WithValue(context.Background(), "trace_id", traceID)
 	sessionCtx = context.WithValue(sessionCtx, "user_id", userID)
 
-	// Set context for this goroutine - all subsequent Emit calls will use this context
-	zlog.SetContext(sessionCtx)
-	defer zlog.ClearContext()
-
-	// User logs in
-	zlog.Emit(USER_LOGIN, "User logged in",
+	// User logs in. Each event carries the session context so sinks can read the trace data
+	zlog.EmitContext(sessionCtx, USER_LOGIN, "User logged in",
 		zlog.String("user_id", userID),
 		zlog.String("ip", "192.168.1.100"),
 		zlog.Time("login_time", time.Now()),
@@ -146,7 +142,7 @@ func simulateUserJourney(userID string) {
 	products := []string{"laptop", "mouse", "keyboard", "monitor"}
 	for i := 0; i < rand.Intn(5)+1; i++ {
 		product := products[rand.Intn(len(products))]
-		zlog.Emit(PRODUCT_VIEWED, "Product viewed",
+		zlog.EmitContext(sessionCtx, PRODUCT_VIEWED, "Product viewed",
 			zlog.String("user_id", userID),
 			zlog.String("product_id", product),
 			zlog.Duration("view_duration", time.Duration(rand.Intn(30))*time.Second),
@@ -155,7 +151,7 @@ func simulateUserJourney(userID string) {
 	}
 
 	// Update cart
-	zlog.Emit(CART_UPDATED, "Items added to cart",
+	zlog.EmitContext(sessionCtx, CART_UPDATED, "Items added to cart",
 		zlog.String("user_id", userID),
 		zlog.Int("item_count", rand.Intn(3)+1),
 		zlog.Float64("cart_value", float64(rand.Intn(1000))+99.99),
@@ -165,7 +161,7 @@ func simulateUserJourney(userID string) {
 	orderID := fmt.Sprintf("ORD-%d", rand.Intn(10000))
 	amount := float64(rand.Intn(500)) + 50.99
 
-	zlog.Emit(ORDER_PLACED, "Order placed",
+	zlog.EmitContext(sessionCtx, ORDER_PLACED, "Order placed",
 		zlog.String("user_id", userID),
 		zlog.String("order_id", orderID),
 		zlog.Float64("amount", amount),
@@ -174,14 +170,14 @@ func simulateUserJourney(userID string) {
 
 	// Process payment
 	if rand.Float32() > 0.1 { // 90% success rate
-		zlog.Emit(PAYMENT_PROCESSED, "Payment successful",
+		zlog.EmitContext(sessionCtx, PAYMENT_PROCESSED, "Payment successful",
 			zlog.String("user_id", userID),
 			zlog.String("order_id", orderID),
 			zlog.Float64("amount", amount),
 			zlog.String("payment_method", "credit_card"),
 		)
 	} else {
-		zlog.Emit(PAYMENT_FAILED, "Payment failed",
+		zlog.EmitContext(sessionCtx, PAYMENT_FAILED, "Payment failed",
 			zlog.String("user_id", userID),
 			zlog.String("order_id", orderID),
 			zlog.Float64("amount", amount),
@@ -0,0 +1,212 @@
+package zlog
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ContextExtractor turns values stored in a context into fields.
+//
+// Extractors run once per event, before routing, so every sink sees the same
+// correlation fields without knowing the context keys involved. Return nil
+// when the context holds nothing of interest.
+type ContextExtractor func(ctx context.Context) []Field
+
+// extractorEntry pairs an extractor with the identifier used by its Registration.
+type extractorEntry struct {
+	extract ContextExtractor
+	id      uint64
+}
+
+// extractors holds the registered extractors. The slice is replaced, never
+// mutated, so events read it without locking.
+var extractors struct {
+	list   atomic.Pointer[[]extractorEntry]
+	nextID uint64
+	mu     sync.Mutex
+}
+
+func init() {
+	RegisterContextExtractor(traceFields)
+	RegisterContextExtractor(requestIDFields)
+}
+
+// RegisterContextExtractor adds an extractor applied to every Fields event
+// that carries a context, such as those emitted with EmitContext. Extracted
+// fields are placed before the event's own fields.
+//
+//	type tenantKey struct{}
+//
+//	zlog.RegisterContextExtractor(func(ctx context.Context) []zlog.Field {
+//	    if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+//	        return []zlog.Field{zlog.String("tenant", tenant)}
+//	    }
+//	    return nil
+//	})
+//
+// Built-in extractors add trace_id and span_id for contexts created with
+// ContextWithTrace, and request_id for ContextWithRequestID. Remove the
+// returned Registration to unregister the extractor.
+func RegisterContextExtractor(extract ContextExtractor) *Registration {
+	extractors.mu.Lock()
+	defer extractors.mu.Unlock()
+
+	extractors.nextID++
+	id := extractors.nextID
+
+	var current []extractorEntry
+	if list := extractors.list.Load(); list != nil {
+		current = *list
+	}
+	next := make([]extractorEntry, 0, len(current)+1)
+	next = append(next, current...)
+	next = append(next, extractorEntry{extract: extract, id: id})
+	extractors.list.Store(&next)
+
+	return newRegistration(func() {
+		extractors.mu.Lock()
+		defer extractors.mu.Unlock()
+
+		current := *extractors.list.Load()
+		kept := make([]extractorEntry, 0, len(current))
+		for _, entry := range current {
+			if entry.id != id {
+				kept = append(kept, entry)
+			}
+		}
+		extractors.list.Store(&kept)
+	})
+}
+
+// withContextFields prepends the fields extracted from ctx to fields.
+func withContextFields(ctx context.Context, fields Fields) Fields {
+	list := extractors.list.Load()
+	if ctx == nil || list == nil {
+		return fields
+	}
+
+	var extracted Fields
+	for _, entry := range *list {
+		extracted = append(extracted, entry.extract(ctx)...)
+	}
+	if len(extracted) == 0 {
+		return fields
+	}
+	return append(extracted, fields...)
+}
+
+// TraceContext identifies the trace and span an event belongs to, following
+// the W3C Trace Context format.
+type TraceContext struct {
+	// TraceID is the 32 hex digit trace identifier.
+	TraceID string
+
+	// SpanID is the 16 hex digit identifier of the current span.
+	SpanID string
+
+	// Sampled reports whether the trace was chosen for recording.
+	Sampled bool
+}
+
+// ParseTraceparent parses a W3C traceparent header such as
+// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+func ParseTraceparent(header string) (TraceContext, error) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 {
+		return TraceContext{}, fmt.Errorf("zlog: malformed traceparent %q", header)
+	}
+
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+	switch {
+	case len(version) != 2 || !isHex(version) || version == "ff":
+		return TraceContext{}, fmt.Errorf("zlog: invalid traceparent version %q", version)
+	case version == "00" && len(parts) != 4:
+		return TraceContext{}, fmt.Errorf("zlog: malformed traceparent %q", header)
+	case len(traceID) != 32 || !isHex(traceID) || strings.Trim(traceID, "0") == "":
+		return TraceContext{}, fmt.Errorf("zlog: invalid trace ID %q", traceID)
+	case len(spanID) != 16 || !isHex(spanID) || strings.Trim(spanID, "0") == "":
+		return TraceContext{}, fmt.Errorf("zlog: invalid span ID %q", spanID)
+	case len(flags) != 2 || !isHex(flags):
+		return TraceContext{}, fmt.Errorf("zlog: invalid trace flags %q", flags)
+	}
+
+	flagBits, _ := hex.DecodeString(flags) //nolint:errcheck // Validated above
+	return TraceContext{
+		TraceID: strings.ToLower(traceID),
+		SpanID:  strings.ToLower(spanID),
+		Sampled: flagBits[0]&0x01 != 0,
+	}, nil
+}
+
+// isHex reports whether s consists only of hexadecimal digits.
+func isHex(s string) bool {
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// traceKey and requestIDKey are the context keys used by the built-in
+// extractors.
+type (
+	traceKey     struct{}
+	requestIDKey struct{}
+)
+
+// ContextWithTrace returns a copy of ctx carrying the trace context.
+// Events emitted with the returned context get trace_id and span_id fields.
+//
+//	trace, err := zlog.ParseTraceparent(r.Header.Get("traceparent"))
+//	if err == nil {
+//	    ctx = zlog.ContextWithTrace(ctx, trace)
+//	}
+//	zlog.InfoContext(ctx, "Request received") // trace_id, span_id
+func ContextWithTrace(ctx context.Context, trace TraceContext) context.Context {
+	return context.WithValue(ctx, traceKey{}, trace)
+}
+
+// TraceFromContext returns the trace context stored by ContextWithTrace.
+func TraceFromContext(ctx context.Context) (TraceContext, bool) {
+	trace, ok := ctx.Value(traceKey{}).(TraceContext)
+	return trace, ok
+}
+
+// ContextWithRequestID returns a copy of ctx carrying a request ID.
+// Events emitted with the returned context get a request_id field.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored by ContextWithRequestID.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	return requestID, ok
+}
+
+// traceFields is the built-in extractor for trace_id and span_id.
+func traceFields(ctx context.Context) []Field {
+	trace, ok := TraceFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	fields := []Field{String("trace_id", trace.TraceID)}
+	if trace.SpanID != "" {
+		fields = append(fields, String("span_id", trace.SpanID))
+	}
+	return fields
+}
+
+// requestIDFields is the built-in extractor for request_id.
+func requestIDFields(ctx context.Context) []Field {
+	if requestID, ok := RequestIDFromContext(ctx); ok && requestID != "" {
+		return []Field{String("request_id", requestID)}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package zlog
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	trace, err := ParseTraceparent("00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trace.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || trace.SpanID != "00f067aa0ba902b7" || !trace.Sampled {
+		t.Errorf("unexpected trace: %+v", trace)
+	}
+
+	// Future versions may append fields
+	if _, err := ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-extra"); err != nil {
+		t.Errorf("future version rejected: %v", err)
+	}
+
+	invalid := []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-zz",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+	}
+	for _, header := range invalid {
+		if _, err := ParseTraceparent(header); err == nil {
+			t.Errorf("expected error for %q", header)
+		}
+	}
+}
+
+func TestBuiltinContextExtractors(t *testing.T) {
+	logger := New()
+	sink, events := captureSink("extract")
+	logger.HookAll(sink)
+
+	ctx := ContextWithTrace(context.Background(), TraceContext{
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:  "00f067aa0ba902b7",
+	})
+	ctx = ContextWithRequestID(ctx, "req-1")
+
+	logger.InfoContext(ctx, "correlated", String("key", "value"))
+	logger.Info("plain")
+
+	got := events()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(got))
+	}
+
+	var keys []string
+	for _, field := range got[0].Data {
+		keys = append(keys, field.Key)
+	}
+	if strings.Join(keys, ",") != "trace_id,span_id,request_id,key" {
+		t.Errorf("fields = %v", keys)
+	}
+	if len(got[1].Data) != 0 {
+		t.Errorf("plain event gained fields: %v", got[1].Data)
+	}
+}
+
+func TestRegisterContextExtractor(t *testing.T) {
+	type tenantKey struct{}
+
+	reg := RegisterContextExtractor(func(ctx context.Context) []Field {
+		if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+			return []Field{String("tenant", tenant)}
+		}
+		return nil
+	})
+
+	logger := New()
+	sink, events := captureSink("tenant")
+	logger.HookAll(sink)
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	logger.EmitContext(ctx, "CUSTOM", "with extractor")
+
+	reg.Remove()
+	logger.EmitContext(ctx, "CUSTOM", "removed")
+
+	got := events()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(got))
+	}
+	if len(got[0].Data) != 1 || got[0].Data[0].Value != "acme" {
+		t.Errorf("expected tenant field, got %v", got[0].Data)
+	}
+	if len(got[1].Data) != 0 {
+		t.Errorf("removed extractor still applied: %v", got[1].Data)
+	}
+}
+
+func TestContextExtractorsViaWatch(t *testing.T) {
+	original := defaultLogger
+	defaultLogger = NewLogger[Fields]()
+	t.Cleanup(func() { defaultLogger = original })
+
+	sink, events := captureSink("watch")
+	HookAll(sink)
+
+	typed := NewLogger[string]().Watch()
+	typed.EmitContext(ContextWithRequestID(context.Background(), "req-2"), "TYPED", "typed", "payload")
+
+	got := events()
+	if len(got) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(got))
+	}
+	if got[0].Data[0].Key != "request_id" {
+		t.Errorf("expected request_id first, got %v", got[0].Data)
+	}
+}
@@ -38,7 +38,20 @@ import (
 // fails, the same event data is passed to the fallback sink. Both sinks
 // receive identical event data for consistent processing.
 func (s *Sink) WithFallback(fallbackSink *Sink) *Sink {
-	return &Sink{
-		processor: pipz.NewFallback("fallback", s.processor, fallbackSink.processor),
-	}
+	fallback := s.branchLayer("fallback", fallbackSink.processor, func(inner pipz.Chainable[Log]) pipz.Chainable[Log] {
+		return pipz.NewFallback("fallback", inner, fallbackSink.processor)
+	})
+
+	// Both sinks' resources are kept so Shutdown flushes and closes each of
+	// them, and both sinks' counters so the fallback appears in Stats too
+	resources := make([]*sinkResource, 0, len(fallback.resources)+len(fallbackSink.resources))
+	resources = append(resources, fallback.resources...)
+	resources = append(resources, fallbackSink.resources...)
+
+	counters := make([]*sinkCounters, 0, len(fallback.stats.counters)+len(fallbackSink.stats.counters))
+	counters = append(counters, fallback.stats.counters...)
+	counters = append(counters, fallbackSink.stats.counters...)
+	fallbackSink.stats.nest()
+
+	return s.derive(fallback.processor, counters, resources)
 }
@@ -1,6 +1,7 @@
 package zlog
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -47,7 +48,7 @@ const (
 	// BoolType for boolean values.
 	BoolType FieldType = "bool"
 
-	// ErrorType for error values (stored as strings).
+	// ErrorType for error values. The value is the error itself.
 	ErrorType FieldType = "error"
 
 	// DurationType for time.Duration values.
@@ -64,6 +65,50 @@ const (
 
 	// StringsType for []string values.
 	StringsType FieldType = "strings"
+
+	// StackType for stack traces created by Stack. Field.Resolve returns the
+	// field with a StackTrace value.
+	StackType FieldType = "stack"
+
+	// UintType for uint values.
+	UintType FieldType = "uint"
+
+	// Uint64Type for uint64 values.
+	Uint64Type FieldType = "uint64"
+
+	// Int32Type for int32 values.
+	Int32Type FieldType = "int32"
+
+	// Float32Type for float32 values.
+	Float32Type FieldType = "float32"
+
+	// IntsType for []int values.
+	IntsType FieldType = "ints"
+
+	// Float64sType for []float64 values.
+	Float64sType FieldType = "float64s"
+
+	// BoolsType for []bool values.
+	BoolsType FieldType = "bools"
+
+	// TimesType for []time.Time values.
+	TimesType FieldType = "times"
+
+	// DurationsType for []time.Duration values.
+	DurationsType FieldType = "durations"
+
+	// GroupType for nested fields created by Group. The value is Fields.
+	GroupType FieldType = "group"
+
+	// ObjectType for ObjectMarshaler values created by Object.
+	ObjectType FieldType = "object"
+
+	// ArrayType for ArrayMarshaler values created by Array.
+	ArrayType FieldType = "array"
+
+	// LazyType for fields created by Lazy, LazyString and LazyInt whose
+	// value has not been computed yet. Sinks receive them resolved.
+	LazyType FieldType = "lazy"
 )
 
 // String creates a string field.
@@ -108,15 +153,28 @@ func Bool(key string, value bool) Field {
 
 // Err creates an error field with key "error".
 //
-// The error is stored as a string. If err is nil, the field value is nil.
+// The error itself is stored, so sinks can use errors.Is and errors.As on
+// it. JSON sinks render it as an object with the message, the error's type
+// and the chain of wrapped causes; see LogFielder for adding structured
+// context. If err is nil, the field value is nil.
 //
 //	zlog.Error("Failed to connect", zlog.Err(err))
 //	zlog.Info("Retry succeeded", zlog.Err(lastErr))
 func Err(err error) Field {
+	return NamedErr("error", err)
+}
+
+// NamedErr creates an error field with a custom key, for events that carry
+// more than one error.
+//
+//	zlog.Error("Failover failed",
+//	    zlog.NamedErr("primary_error", primaryErr),
+//	    zlog.NamedErr("fallback_error", fallbackErr))
+func NamedErr(key string, err error) Field {
 	if err == nil {
-		return Field{Key: "error", Type: ErrorType, Value: nil}
+		return Field{Key: key, Type: ErrorType, Value: nil}
 	}
-	return Field{Key: "error", Type: ErrorType, Value: err.Error()}
+	return Field{Key: key, Type: ErrorType, Value: err}
 }
 
 // Duration creates a time duration field.
@@ -165,3 +223,164 @@ func Strings(key string, value []string) Field {
 func Data[T any](key string, value T) Field {
 	return Field{Key: key, Type: DataType, Value: value}
 }
+
+// Uint creates an unsigned integer field.
+//
+//	zlog.Uint("retries", retries)
+func Uint(key string, value uint) Field {
+	return Field{Key: key, Type: UintType, Value: value}
+}
+
+// Uint64 creates a 64-bit unsigned integer field.
+//
+//	zlog.Uint64("bytes_sent", written)
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, Type: Uint64Type, Value: value}
+}
+
+// Int32 creates a 32-bit integer field.
+//
+//	zlog.Int32("partition", msg.Partition)
+func Int32(key string, value int32) Field {
+	return Field{Key: key, Type: Int32Type, Value: value}
+}
+
+// Float32 creates a 32-bit floating-point field.
+//
+//	zlog.Float32("score", result.Score)
+func Float32(key string, value float32) Field {
+	return Field{Key: key, Type: Float32Type, Value: value}
+}
+
+// Ints creates a field for int slices.
+//
+//	zlog.Ints("shard_ids", shards)
+func Ints(key string, value []int) Field {
+	return Field{Key: key, Type: IntsType, Value: value}
+}
+
+// Float64s creates a field for float64 slices.
+//
+//	zlog.Float64s("percentiles", []float64{p50, p95, p99})
+func Float64s(key string, value []float64) Field {
+	return Field{Key: key, Type: Float64sType, Value: value}
+}
+
+// Bools creates a field for bool slices.
+//
+//	zlog.Bools("checks", []bool{dbOK, cacheOK})
+func Bools(key string, value []bool) Field {
+	return Field{Key: key, Type: BoolsType, Value: value}
+}
+
+// Times creates a field for time.Time slices.
+//
+//	zlog.Times("attempted_at", attempts)
+func Times(key string, value []time.Time) Field {
+	return Field{Key: key, Type: TimesType, Value: value}
+}
+
+// Durations creates a field for time.Duration slices.
+//
+//	zlog.Durations("phase_latencies", phases)
+func Durations(key string, value []time.Duration) Field {
+	return Field{Key: key, Type: DurationsType, Value: value}
+}
+
+// Stringer creates a string field from value's String method. The method is
+// only called if the event reaches a sink, like a LazyString field, and a
+// nil value or a panicking String method is rendered the way fmt does.
+//
+//	zlog.Stringer("addr", conn.RemoteAddr())
+func Stringer(key string, value fmt.Stringer) Field {
+	return newLazy(key, StringType, func() any { return fmt.Sprint(value) })
+}
+
+// Group creates a field that nests other fields under key. JSON sinks render
+// it as an object and the console sink as an indented subtree:
+//
+//	zlog.Info("Request completed",
+//	    zlog.Group("http",
+//	        zlog.String("method", r.Method),
+//	        zlog.Int("status", status)))
+//
+//	// {"message":"Request completed","http":{"method":"GET","status":200}}
+func Group(key string, fields ...Field) Field {
+	return Field{Key: key, Type: GroupType, Value: Fields(fields)}
+}
+
+// Any creates a field using the constructor that best fits the value's
+// type, falling back to Data for anything else:
+//
+//	zlog.Any("status", 200)          // Int
+//	zlog.Any("tags", []string{"a"})  // Strings
+//	zlog.Any("user", user)           // Data, or Stringer if User has String()
+//
+// Prefer the typed constructors where the type is known; Any is for generic
+// code such as adapters from other logging APIs.
+func Any(key string, value any) Field {
+	switch v := value.(type) {
+	case nil:
+		return Data[any](key, nil)
+	case string:
+		return String(key, v)
+	case bool:
+		return Bool(key, v)
+	case int:
+		return Int(key, v)
+	case int8:
+		return Int32(key, int32(v))
+	case int16:
+		return Int32(key, int32(v))
+	case int32:
+		return Int32(key, v)
+	case int64:
+		return Int64(key, v)
+	case uint:
+		return Uint(key, v)
+	case uint8:
+		return Uint(key, uint(v))
+	case uint16:
+		return Uint(key, uint(v))
+	case uint32:
+		return Uint64(key, uint64(v))
+	case uint64:
+		return Uint64(key, v)
+	case float32:
+		return Float32(key, v)
+	case float64:
+		return Float64(key, v)
+	case time.Duration:
+		return Duration(key, v)
+	case time.Time:
+		return Time(key, v)
+	case []byte:
+		return ByteString(key, v)
+	case []string:
+		return Strings(key, v)
+	case []int:
+		return Ints(key, v)
+	case []float64:
+		return Float64s(key, v)
+	case []bool:
+		return Bools(key, v)
+	case []time.Time:
+		return Times(key, v)
+	case []time.Duration:
+		return Durations(key, v)
+	case Fields:
+		return Group(key, v...)
+	case []Field:
+		return Group(key, v...)
+	case ObjectMarshaler:
+		return Object(key, v)
+	case ArrayMarshaler:
+		return Array(key, v)
+	case error:
+		return NamedErr(key, v)
+	case fmt.Stringer:
+		return Stringer(key, v)
+	default:
+		return Data(key, value)
+	}
+}
@@ -2,6 +2,9 @@ package zlog
 
 import (
 	"errors"
+	"fmt"
+	"net"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -111,13 +114,13 @@ func TestFieldConstructors(t *testing.T) {
 
 			// Special handling for error comparison
 			if tt.wantType == ErrorType {
-				// Err() function stores error as string
-				gotStr, ok1 := tt.field.Value.(string)
+				// Err() stores the error itself; compare messages
+				gotErr, ok1 := tt.field.Value.(error)
 				wantErr, ok2 := tt.wantValue.(error)
 				if !ok1 || !ok2 {
 					t.Errorf("Error field value type mismatch")
-				} else if gotStr != wantErr.Error() {
-					t.Errorf("Error value = %v, want %v", gotStr, wantErr.Error())
+				} else if gotErr.Error() != wantErr.Error() {
+					t.Errorf("Error value = %v, want %v", gotErr, wantErr)
 				}
 			} else {
 				// Use deep equal for slices
@@ -188,3 +191,120 @@ func TestFieldEdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestExpandedFieldConstructors(t *testing.T) {
+	when := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		field     Field
+		wantType  FieldType
+		wantValue any
+	}{
+		{"Uint", Uint("n", 7), UintType, uint(7)},
+		{"Uint64", Uint64("n", 1<<40), Uint64Type, uint64(1 << 40)},
+		{"Int32", Int32("n", -7), Int32Type, int32(-7)},
+		{"Float32", Float32("n", 0.5), Float32Type, float32(0.5)},
+		{"Ints", Ints("n", []int{1, 2}), IntsType, []int{1, 2}},
+		{"Float64s", Float64s("n", []float64{0.5}), Float64sType, []float64{0.5}},
+		{"Bools", Bools("n", []bool{true}), BoolsType, []bool{true}},
+		{"Times", Times("n", []time.Time{when}), TimesType, []time.Time{when}},
+		{"Durations", Durations("n", []time.Duration{time.Second}), DurationsType, []time.Duration{time.Second}},
+		{"Group", Group("n", String("a", "b")), GroupType, Fields{String("a", "b")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.field.Key != "n" || tt.field.Type != tt.wantType {
+				t.Errorf("field = %s/%s, want n/%s", tt.field.Key, tt.field.Type, tt.wantType)
+			}
+			if !reflect.DeepEqual(tt.field.Value, tt.wantValue) {
+				t.Errorf("Value = %#v, want %#v", tt.field.Value, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestStringer(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8080}
+	field := Stringer("addr", addr)
+	if field.Type != LazyType {
+		t.Errorf("Stringer should defer String until resolved, got type %s", field.Type)
+	}
+
+	resolved := field.Resolve()
+	if resolved.Type != StringType || resolved.Value != "127.0.0.1:8080" {
+		t.Errorf("resolved = %+v", resolved)
+	}
+
+	var missing *net.TCPAddr
+	if got := Stringer("addr", missing).Resolve().Value; got != "<nil>" {
+		t.Errorf("nil Stringer resolved to %v, want <nil>", got)
+	}
+}
+
+func TestAny(t *testing.T) {
+	errBoom := errors.New("boom")
+	when := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value     any
+		wantType  FieldType
+		wantValue any
+	}{
+		{nil, DataType, nil},
+		{"s", StringType, "s"},
+		{true, BoolType, true},
+		{1, IntType, 1},
+		{int8(1), Int32Type, int32(1)},
+		{int16(1), Int32Type, int32(1)},
+		{int32(1), Int32Type, int32(1)},
+		{int64(1), Int64Type, int64(1)},
+		{uint(1), UintType, uint(1)},
+		{uint8(1), UintType, uint(1)},
+		{uint16(1), UintType, uint(1)},
+		{uint32(1), Uint64Type, uint64(1)},
+		{uint64(1), Uint64Type, uint64(1)},
+		{float32(1), Float32Type, float32(1)},
+		{1.5, Float64Type, 1.5},
+		{time.Second, DurationType, time.Second},
+		{when, TimeType, when},
+		{[]byte("b"), ByteStringType, "b"},
+		{[]string{"a"}, StringsType, []string{"a"}},
+		{[]int{1}, IntsType, []int{1}},
+		{[]float64{1}, Float64sType, []float64{1}},
+		{[]bool{true}, BoolsType, []bool{true}},
+		{[]time.Time{when}, TimesType, []time.Time{when}},
+		{[]time.Duration{time.Second}, DurationsType, []time.Duration{time.Second}},
+		{Fields{Int("a", 1)}, GroupType, Fields{Int("a", 1)}},
+		{[]Field{Int("a", 1)}, GroupType, Fields{Int("a", 1)}},
+		{errBoom, ErrorType, errBoom},
+		{&net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 80}, StringType, "10.0.0.1:80"},
+		{struct{ ID int }{1}, DataType, struct{ ID int }{1}},
+	}
+
+	for _, tt := range tests {
+		field := Any("k", tt.value).Resolve()
+		if field.Key != "k" || field.Type != tt.wantType {
+			t.Errorf("Any(%#v): type = %s, want %s", tt.value, field.Type, tt.wantType)
+			continue
+		}
+		if !reflect.DeepEqual(field.Value, tt.wantValue) {
+			t.Errorf("Any(%#v): value = %#v, want %#v", tt.value, field.Value, tt.wantValue)
+		}
+	}
+}
+
+func TestNamedErr(t *testing.T) {
+	cause := errors.New("refused")
+	field := NamedErr("primary_error", fmt.Errorf("dial: %w", cause))
+	if field.Key != "primary_error" || field.Type != ErrorType {
+		t.Errorf("field = %+v", field)
+	}
+	err, ok := field.Value.(error)
+	if !ok || !errors.Is(err, cause) {
+		t.Errorf("expected the wrapped error to be kept, got %#v", field.Value)
+	}
+
+	if field := NamedErr("primary_error", nil); field.Value != nil {
+		t.Errorf("nil error value = %v, want nil", field.Value)
+	}
+}
@@ -2,11 +2,9 @@ package zlog
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"sync"
-	"time"
 )
 
 // rotatingFileWriter manages file rotation and writing operations.
@@ -14,7 +12,7 @@ type rotatingFileWriter struct { //nolint:govet // Field ordering is logical, no
 	mu          sync.Mutex
 	currentFile *os.File
 	filename    string
-	maxSize     int64
+	maxSize     int64 // Zero never rotates
 	currentSize int64
 	maxFiles    int
 }
@@ -42,6 +40,16 @@ func newRotatingFileWriter(filename string, maxSize int64, maxFiles int) (*rotat
 	return writer, nil
 }
 
+// newAppendFileWriter creates a writer that only ever appends to filename,
+// never rotating or deleting anything.
+func newAppendFileWriter(filename string) (*rotatingFileWriter, error) {
+	writer := &rotatingFileWriter{filename: filename}
+	if err := writer.openFile(); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
 // openFile opens the current log file and gets its size.
 func (w *rotatingFileWriter) openFile() error {
 	file, err := os.OpenFile(w.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
@@ -66,8 +74,12 @@ func (w *rotatingFileWriter) write(data []byte) error {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
+	if w.currentFile == nil {
+		return fmt.Errorf("log file %s is closed", w.filename)
+	}
+
 	// Check if we need to rotate before writing
-	if w.currentSize+int64(len(data)) > w.maxSize {
+	if w.maxSize > 0 && w.currentSize+int64(len(data)) > w.maxSize {
 		if err := w.rotate(); err != nil {
 			// Log rotation failed, but we can still try to write to current file
 			// This prevents losing log entries due to rotation issues
@@ -85,6 +97,36 @@ func (w *rotatingFileWriter) write(data []byte) error {
 	return nil
 }
 
+// sync commits the current file contents to stable storage.
+func (w *rotatingFileWriter) sync(_ context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.currentFile == nil {
+		return nil
+	}
+	if err := w.currentFile.Sync(); err != nil {
+		return fmt.Errorf("failed to sync log file %s: %w", w.filename, err)
+	}
+	return nil
+}
+
+// close closes the current file. Later writes fail instead of reopening it.
+func (w *rotatingFileWriter) close(_ context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.currentFile == nil {
+		return nil
+	}
+	err := w.currentFile.Close()
+	w.currentFile = nil
+	if err != nil {
+		return fmt.Errorf("failed to close log file %s: %w", w.filename, err)
+	}
+	return nil
+}
+
 // rotate performs the file rotation.
 func (w *rotatingFileWriter) rotate() error {
 	// Close current file
@@ -118,15 +160,27 @@ func (w *rotatingFileWriter) rotate() error {
 	return w.openFile()
 }
 
-// NewRotatingFileSink creates a sink that writes JSON-formatted events to rotating files.
+// FileOption configures NewRotatingFileSink. WithEncoder is a FileOption.
+type FileOption interface {
+	applyFile(config *fileConfig)
+}
+
+// fileConfig holds configuration for the rotating file sink.
+type fileConfig struct {
+	encoder Encoder
+}
+
+// NewRotatingFileSink creates a sink that writes events to rotating files.
 //
-// The sink writes events in the same JSON format as the stderr sink, making it compatible
-// with log aggregation systems. Files are rotated when they exceed maxSize bytes.
+// By default the sink writes events in the same JSON format as the stderr
+// sink, making it compatible with log aggregation systems; pass WithEncoder
+// to choose another format. Files are rotated when they exceed maxSize bytes.
 //
 // Parameters:
 //   - filename: Path to the log file (e.g., "app.log")
 //   - maxSize: Maximum file size in bytes before rotation (0 = 100MB default)
 //   - maxFiles: Maximum number of rotated files to keep (0 = 5 default)
+//   - options: Optional configuration such as WithEncoder
 //
 // File naming pattern:
 //   - app.log (current log file)
@@ -138,6 +192,10 @@ func (w *rotatingFileWriter) rotate() error {
 //	// Create a file sink with 100MB rotation and keep 5 files
 //	fileSink := zlog.NewRotatingFileSink("logs/app.log", 100*1024*1024, 5)
 //
+//	// Write logfmt instead of JSON
+//	logfmtSink := zlog.NewRotatingFileSink("logs/app.log", 0, 0,
+//	    zlog.WithEncoder(zlog.LogfmtEncoder{}))
+//
 //	// Use with adapters for production reliability
 //	productionSink := fileSink.
 //	    WithRetry(3).
@@ -150,7 +208,15 @@ func (w *rotatingFileWriter) rotate() error {
 //
 // The sink is thread-safe and can handle concurrent writes from multiple goroutines.
 // If rotation fails, the sink continues writing to the current file to avoid losing events.
-func NewRotatingFileSink(filename string, maxSize int64, maxFiles int) *Sink {
+//
+// Flush syncs the file to disk and Close closes it; both happen automatically
+// during zlog.Shutdown for hooked sinks.
+func NewRotatingFileSink(filename string, maxSize int64, maxFiles int, options ...FileOption) *Sink {
+	config := &fileConfig{encoder: JSONEncoder{}}
+	for _, option := range options {
+		option.applyFile(config)
+	}
+
 	// Create the writer once during sink creation
 	writer, err := newRotatingFileWriter(filename, maxSize, maxFiles)
 	if err != nil {
@@ -160,34 +226,17 @@ func NewRotatingFileSink(filename string, maxSize int64, maxFiles int) *Sink {
 		})
 	}
 
-	return NewSink("rotating-file", func(_ context.Context, event Log) error {
-		// Build JSON structure (same format as stderr sink)
-		entry := map[string]interface{}{
-			"time":    event.Time.Format(time.RFC3339Nano),
-			"signal":  string(event.Signal),
-			"message": event.Message,
-		}
-
-		// Add caller info if available
-		if event.Caller.File != "" {
-			entry["caller"] = fmt.Sprintf("%s:%d", event.Caller.File, event.Caller.Line)
-		}
-
-		// Add all structured fields as top-level JSON properties
-		for _, field := range event.Data {
-			entry[field.Key] = field.Value
-		}
+	sink := NewSink("rotating-file", func(_ context.Context, event Log) error {
+		buf := getBuffer()
+		defer putBuffer(buf)
 
-		// Encode to JSON
-		data, err := json.Marshal(entry)
-		if err != nil {
-			return fmt.Errorf("failed to marshal event to JSON: %w", err)
+		if err := config.encoder.Encode(event, buf); err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
 		}
 
-		// Add newline for proper log formatting
-		data = append(data, '\n')
-
 		// Write to rotating file
-		return writer.write(data)
+		return writer.write(buf.Bytes())
 	})
+
+	return sink.withResource(&sinkResource{flush: writer.sync, close: writer.close})
 }
@@ -64,7 +64,12 @@ import (
 // The predicate function should be fast since it's called for every
 // event routed to this sink. Avoid expensive operations in the filter.
 func (s *Sink) WithFilter(predicate func(context.Context, Log) bool) *Sink {
-	return &Sink{
-		processor: pipz.NewFilter[Log]("filter", predicate, s.processor),
-	}
+	return s.filterLayer("filter", predicate)
+}
+
+// filterLayer adds a filter whose statistics are reported under kind.
+func (s *Sink) filterLayer(kind string, predicate func(context.Context, Log) bool) *Sink {
+	return s.layer(kind, func(inner pipz.Chainable[Log]) pipz.Chainable[Log] {
+		return pipz.NewFilter[Log]("filter", predicate, inner)
+	})
 }
@@ -3,17 +3,28 @@ package zlog
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 )
 
-// HTTPOption configures HTTP sink behavior using the functional options pattern.
-type HTTPOption func(*httpConfig)
+// HTTPOption configures HTTP sink behavior using the functional options
+// pattern. WithEncoder is also an HTTPOption.
+type HTTPOption interface {
+	applyHTTP(config *httpConfig)
+}
+
+// httpOption adapts a function to HTTPOption.
+type httpOption func(*httpConfig)
+
+// applyHTTP implements HTTPOption.
+func (o httpOption) applyHTTP(config *httpConfig) {
+	o(config)
+}
 
 // httpConfig holds configuration for HTTP sink.
 type httpConfig struct {
+	encoder   Encoder
 	headers   map[string]string
 	method    string
 	userAgent string
@@ -22,45 +33,45 @@ type httpConfig struct {
 
 // WithMethod sets the HTTP method for requests (default: POST).
 func WithMethod(method string) HTTPOption {
-	return func(config *httpConfig) {
+	return httpOption(func(config *httpConfig) {
 		if method != "" {
 			config.method = method
 		}
-	}
+	})
 }
 
 // WithHeaders sets custom HTTP headers for requests.
 // Common use cases:
 //   - Authorization: "Bearer token123"
-//   - Content-Type: "application/json" (set automatically)
+//   - Content-Type: "application/json" (set automatically from the encoder)
 //   - X-API-Key: "key123"
 func WithHeaders(headers map[string]string) HTTPOption {
-	return func(config *httpConfig) {
+	return httpOption(func(config *httpConfig) {
 		if config.headers == nil {
 			config.headers = make(map[string]string)
 		}
 		for k, v := range headers {
 			config.headers[k] = v
 		}
-	}
+	})
 }
 
 // WithTimeout sets the HTTP request timeout (default: 30 seconds).
 func WithTimeout(timeout time.Duration) HTTPOption {
-	return func(config *httpConfig) {
+	return httpOption(func(config *httpConfig) {
 		if timeout > 0 {
 			config.timeout = timeout
 		}
-	}
+	})
 }
 
 // WithUserAgent sets a custom User-Agent header (default: "zlog-http-sink/1.0").
 func WithUserAgent(userAgent string) HTTPOption {
-	return func(config *httpConfig) {
+	return httpOption(func(config *httpConfig) {
 		if userAgent != "" {
 			config.userAgent = userAgent
 		}
-	}
+	})
 }
 
 // NewHTTPSink creates a sink that sends JSON-formatted events to an HTTP endpoint.
@@ -69,13 +80,13 @@ func WithUserAgent(userAgent string) HTTPOption {
 // and custom log collectors. It provides:
 //   - Zero external dependencies (uses only Go stdlib)
 //   - Same JSON format as other zlog sinks for consistency
-//   - Configurable HTTP method, headers, and timeout
+//   - Configurable HTTP method, headers, timeout and encoder
 //   - Robust error handling for network failures
 //   - Full compatibility with all sink adapters
 //
-// JSON payload format:
+// Default JSON payload format:
 //
-//	{"time":"2023-10-20T15:04:05Z","signal":"ERROR","message":"Database connection failed","caller":"db.go:123","error":"connection timeout"}
+//	{"time":"2023-10-20T15:04:05Z","signal":"ERROR","message":"Database connection failed","caller":"db.go:123","error":{"message":"connection timeout","type":"*errors.errorString"}}
 //
 // Parameters:
 //   - url: The HTTP endpoint to send events to
@@ -111,6 +122,13 @@ func WithUserAgent(userAgent string) HTTPOption {
 // compatible with retry and fallback adapters. Network timeouts and connection
 // failures are also handled gracefully.
 //
+// WithEncoder changes the payload format. The Content-Type header comes from
+// the encoder's ContentType method when it has one, as the built-in
+// encoders do, and is text/plain otherwise:
+//
+//	lokiSink := zlog.NewHTTPSink("https://logs.example.com/ingest",
+//	    zlog.WithEncoder(zlog.LogfmtEncoder{}))
+//
 // HTTP status codes 200-299 are considered successful. All other status codes
 // result in an error that includes the response status and body (if available).
 func NewHTTPSink(url string, options ...HTTPOption) *Sink {
@@ -120,11 +138,18 @@ func NewHTTPSink(url string, options ...HTTPOption) *Sink {
 		headers:   make(map[string]string),
 		timeout:   30 * time.Second,
 		userAgent: "zlog-http-sink/1.0",
+		encoder:   JSONEncoder{},
 	}
 
 	// Apply functional options
 	for _, option := range options {
-		option(config)
+		option.applyHTTP(config)
+	}
+
+	// The encoder decides the default Content-Type; custom headers can override it
+	contentType := "text/plain; charset=utf-8"
+	if typed, ok := config.encoder.(interface{ ContentType() string }); ok {
+		contentType = typed.ContentType()
 	}
 
 	// Validate URL
@@ -140,37 +165,20 @@ func NewHTTPSink(url string, options ...HTTPOption) *Sink {
 	}
 
 	return NewSink("http", func(ctx context.Context, event Log) error {
-		// Build JSON structure (same format as other zlog sinks)
-		entry := map[string]interface{}{
-			"time":    event.Time.Format(time.RFC3339Nano),
-			"signal":  string(event.Signal),
-			"message": event.Message,
-		}
-
-		// Add caller info if available
-		if event.Caller.File != "" {
-			entry["caller"] = fmt.Sprintf("%s:%d", event.Caller.File, event.Caller.Line)
-		}
-
-		// Add all structured fields as top-level JSON properties
-		for _, field := range event.Data {
-			entry[field.Key] = field.Value
-		}
-
-		// Encode to JSON
-		jsonData, err := json.Marshal(entry)
-		if err != nil {
-			return fmt.Errorf("failed to marshal event to JSON: %w", err)
+		// The body must outlive the request, so this buffer is not pooled
+		body := &Buffer{}
+		if err := config.encoder.Encode(event, body); err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
 		}
 
 		// Create HTTP request
-		req, err := http.NewRequestWithContext(ctx, config.method, url, bytes.NewReader(jsonData))
+		req, err := http.NewRequestWithContext(ctx, config.method, url, bytes.NewReader(body.Bytes()))
 		if err != nil {
 			return fmt.Errorf("failed to create HTTP request: %w", err)
 		}
 
 		// Set headers
-		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Type", contentType)
 		req.Header.Set("User-Agent", config.userAgent)
 
 		// Apply custom headers
@@ -0,0 +1,222 @@
+package zlog
+
+import (
+	"context"
+	"os"
+)
+
+// FieldsLogger is an independent logger for structured Log events.
+//
+// The package-level functions (zlog.Info, zlog.Hook, ...) all share one
+// default logger, so two libraries in the same binary share routing. A
+// FieldsLogger has its own routing table, letting each component own its
+// configuration and letting tests run without stomping on each other:
+//
+//	logger := zlog.New()
+//	logger.Hook(zlog.ERROR, alertSink)
+//	logger.Info("Cache warmed", zlog.Int("entries", n))
+//
+// FieldsLogger embeds *Logger[Fields], so Register, Unhook, ReplaceHooks,
+// Shutdown and the other Logger methods are available as well. Hook and
+// HookAll are narrowed to accept sinks directly, mirroring the package API.
+type FieldsLogger struct {
+	*Logger[Fields]
+	fields []Field // Bound by With, prepended to every event
+	skip   int     // Extra frames between the user's call site and this logger
+}
+
+// New creates an independent logger with its own routing.
+//
+// The logger starts with no routes. Hook sinks to it directly, or install it
+// as the package default with SetDefault:
+//
+//	logger := zlog.New()
+//	logger.Hook(zlog.INFO, zlog.NewPrettyConsoleSink())
+//	zlog.SetDefault(logger)
+func New() *FieldsLogger {
+	return &FieldsLogger{Logger: NewLogger[Fields]()}
+}
+
+// emitFields builds a Log event and processes it through the logger.
+// Bound fields from With come before the fields of the call. The skip
+// parameter is the number of frames between emitFields and the user's call
+// site, so every public entry point reports the right caller.
+func emitFields(ctx context.Context, l *Logger[Fields], skip int, signal Signal, msg string, bound, fields []Field) {
+	var stack *lazyStack
+	if wantsStack(signal, bound, fields) && l.Enabled(signal) {
+		stack = captureStack(skip + 1)
+	}
+
+	event := NewEvent(signal, msg, eventData(bound, fields, stack))
+	event.Caller = captureCallerInfo(skip + 1)
+	l.ProcessContext(ctx, event)
+}
+
+// eventData joins bound fields, the fields of one call and, unless it is nil,
+// an automatic stack trace into the Data of an event. It allocates once,
+// sized for the result, and not at all when one slice can be used as is.
+func eventData(bound, fields []Field, stack *lazyStack) []Field {
+	switch {
+	case stack == nil && len(bound) == 0:
+		return fields
+	case stack == nil && len(fields) == 0:
+		// Cap the slice so nothing downstream can append into the shared array
+		return bound[:len(bound):len(bound)]
+	}
+
+	size := len(bound) + len(fields)
+	if stack != nil {
+		size++
+	}
+	data := make([]Field, 0, size)
+	data = append(data, bound...)
+	data = append(data, fields...)
+	if stack != nil {
+		data = append(data, Field{Key: "stack", Type: StackType, Value: stack})
+	}
+	return data
+}
+
+// exitAfterShutdown drains the logger with a bounded deadline and exits.
+func exitAfterShutdown(l *Logger[Fields]) {
+	ctx, cancel := context.WithTimeout(context.Background(), fatalShutdownTimeout)
+	_ = l.Shutdown(ctx) //nolint:errcheck // Nothing useful to do with the error while exiting
+	cancel()
+	os.Exit(1)
+}
+
+// Emit sends an event with the specified signal through this logger.
+// See the package-level Emit for details.
+func (l *FieldsLogger) Emit(signal Signal, msg string, fields ...Field) {
+	emitFields(getContext(), l.Logger, 1+l.skip, signal, msg, l.fields, fields)
+}
+
+// EmitContext sends an event with the specified signal through this logger,
+// passing ctx to every sink. See the package-level EmitContext for details.
+func (l *FieldsLogger) EmitContext(ctx context.Context, signal Signal, msg string, fields ...Field) {
+	emitFields(ctx, l.Logger, 1+l.skip, signal, msg, l.fields, fields)
+}
+
+// DebugContext emits a DEBUG event carrying ctx through this logger.
+func (l *FieldsLogger) DebugContext(ctx context.Context, msg string, fields ...Field) {
+	emitFields(ctx, l.Logger, 1+l.skip, DEBUG, msg, l.fields, fields)
+}
+
+// InfoContext emits an INFO event carrying ctx through this logger.
+func (l *FieldsLogger) InfoContext(ctx context.Context, msg string, fields ...Field) {
+	emitFields(ctx, l.Logger, 1+l.skip, INFO, msg, l.fields, fields)
+}
+
+// WarnContext emits a WARN event carrying ctx through this logger.
+func (l *FieldsLogger) WarnContext(ctx context.Context, msg string, fields ...Field) {
+	emitFields(ctx, l.Logger, 1+l.skip, WARN, msg, l.fields, fields)
+}
+
+// ErrorContext emits an ERROR event carrying ctx through this logger.
+func (l *FieldsLogger) ErrorContext(ctx context.Context, msg string, fields ...Field) {
+	emitFields(ctx, l.Logger, 1+l.skip, ERROR, msg, l.fields, fields)
+}
+
+// FatalContext emits a FATAL event carrying ctx through this logger and
+// exits like Fatal.
+func (l *FieldsLogger) FatalContext(ctx context.Context, msg string, fields ...Field) {
+	emitFields(ctx, l.Logger, 1+l.skip, FATAL, msg, l.fields, fields)
+	exitAfterShutdown(l.Logger)
+}
+
+// EmitSkip works like Emit but reports the caller skip frames further up the
+// stack. See the package-level EmitSkip for details.
+func (l *FieldsLogger) EmitSkip(skip int, signal Signal, msg string, fields ...Field) {
+	emitFields(getContext(), l.Logger, 1+l.skip+skip, signal, msg, l.fields, fields)
+}
+
+// WithCallerSkip returns a logger that reports callers skip frames further up
+// the stack. The returned logger shares routing with l, so hooks added to
+// either apply to both.
+//
+//	// In a logging helper package
+//	var log = appLogger.WithCallerSkip(1)
+//
+//	func Audit(msg string, fields ...zlog.Field) {
+//	    log.Emit(AUDIT, msg, fields...) // Reports Audit's caller
+//	}
+func (l *FieldsLogger) WithCallerSkip(skip int) *FieldsLogger {
+	return &FieldsLogger{Logger: l.Logger, fields: l.fields, skip: l.skip + skip}
+}
+
+// With returns a child logger that adds the given fields to every event it
+// emits. Children share routing with their parent and can be nested:
+//
+//	reqLog := logger.With(zlog.String("request_id", id))
+//	dbLog := reqLog.With(zlog.String("component", "db"))
+//	dbLog.Info("Query executed") // request_id, component
+//
+// The bound fields are stored once and come before the fields passed to each
+// call. Creating a child is cheap, so one per request is fine.
+func (l *FieldsLogger) With(fields ...Field) *FieldsLogger {
+	if len(fields) == 0 {
+		return l
+	}
+
+	bound := make([]Field, 0, len(l.fields)+len(fields))
+	bound = append(bound, l.fields...)
+	bound = append(bound, fields...)
+	return &FieldsLogger{Logger: l.Logger, fields: bound, skip: l.skip}
+}
+
+// Debug emits a DEBUG event through this logger.
+func (l *FieldsLogger) Debug(msg string, fields ...Field) {
+	emitFields(getContext(), l.Logger, 1+l.skip, DEBUG, msg, l.fields, fields)
+}
+
+// Info emits an INFO event through this logger.
+func (l *FieldsLogger) Info(msg string, fields ...Field) {
+	emitFields(getContext(), l.Logger, 1+l.skip, INFO, msg, l.fields, fields)
+}
+
+// Warn emits a WARN event through this logger.
+func (l *FieldsLogger) Warn(msg string, fields ...Field) {
+	emitFields(getContext(), l.Logger, 1+l.skip, WARN, msg, l.fields, fields)
+}
+
+// Error emits an ERROR event through this logger.
+func (l *FieldsLogger) Error(msg string, fields ...Field) {
+	emitFields(getContext(), l.Logger, 1+l.skip, ERROR, msg, l.fields, fields)
+}
+
+// Fatal emits a FATAL event through this logger, shuts the logger down with
+// a bounded deadline and terminates the application with os.Exit(1).
+func (l *FieldsLogger) Fatal(msg string, fields ...Field) {
+	emitFields(getContext(), l.Logger, 1+l.skip, FATAL, msg, l.fields, fields)
+	exitAfterShutdown(l.Logger)
+}
+
+// Hook registers one or more sinks to process events with the specified
+// signal on this logger. See the package-level Hook for details.
+func (l *FieldsLogger) Hook(signal Signal, sinks ...*Sink) *Registration {
+	return l.Register(signal, sinkChainables(sinks)...)
+}
+
+// HookAll registers one or more sinks to process every event emitted to this
+// logger. See the package-level HookAll for details.
+func (l *FieldsLogger) HookAll(sinks ...*Sink) *Registration {
+	return l.RegisterAll(sinkChainables(sinks)...)
+}
+
+// HookPattern registers sinks for every signal on this logger matching a
+// glob pattern. See the package-level HookPattern for details.
+func (l *FieldsLogger) HookPattern(pattern string, sinks ...*Sink) *Registration {
+	return l.RegisterMatch(MatchGlob(pattern), sinkChainables(sinks)...)
+}
+
+// HookMatch registers sinks for every signal on this logger accepted by the
+// matcher.
+func (l *FieldsLogger) HookMatch(matcher SignalMatcher, sinks ...*Sink) *Registration {
+	return l.RegisterMatch(matcher, sinkChainables(sinks)...)
+}
+
+// HookUnmatched registers sinks for events on this logger whose signal has no
+// exact or pattern route.
+func (l *FieldsLogger) HookUnmatched(sinks ...*Sink) *Registration {
+	return l.RegisterUnmatched(sinkChainables(sinks)...)
+}
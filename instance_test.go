@@ -0,0 +1,204 @@
+package zlog
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// captureSink returns a sink that records events and a function to read them.
+func captureSink(name string) (*Sink, func() []Log) {
+	var mu sync.Mutex
+	var events []Log
+	sink := NewSink(name, func(_ context.Context, event Log) error {
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+		return nil
+	})
+	return sink, func() []Log {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]Log(nil), events...)
+	}
+}
+
+func TestNewIsolated(t *testing.T) {
+	first := New()
+	second := New()
+
+	firstSink, firstEvents := captureSink("first")
+	secondSink, secondEvents := captureSink("second")
+	first.Hook(INFO, firstSink)
+	second.HookAll(secondSink)
+
+	first.Info("only first")
+	second.Warn("only second")
+
+	if got := len(firstEvents()); got != 1 {
+		t.Errorf("expected 1 event on first logger, got %d", got)
+	}
+	events := secondEvents()
+	if len(events) != 1 || events[0].Signal != WARN {
+		t.Errorf("expected 1 WARN event on second logger, got %v", events)
+	}
+}
+
+func TestFieldsLoggerMethods(t *testing.T) {
+	logger := New()
+	sink, events := captureSink("capture")
+	logger.HookAll(sink)
+
+	logger.Debug("debug")
+	logger.Info("info", String("key", "value"))
+	logger.Warn("warn")
+	logger.Error("error")
+	logger.Emit("CUSTOM", "custom")
+
+	got := events()
+	want := []Signal{DEBUG, INFO, WARN, ERROR, "CUSTOM"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(got))
+	}
+	for i, signal := range want {
+		if got[i].Signal != signal {
+			t.Errorf("event %d: expected signal %s, got %s", i, signal, got[i].Signal)
+		}
+	}
+	if len(got[1].Data) != 1 || got[1].Data[0].Key != "key" {
+		t.Errorf("expected fields to be preserved, got %v", got[1].Data)
+	}
+}
+
+func TestFieldsLoggerCallerCapture(t *testing.T) {
+	logger := New()
+	sink, events := captureSink("caller")
+	logger.HookAll(sink)
+
+	logger.Info("method call")
+	logger.Emit("CUSTOM", "method emit")
+
+	for _, event := range events() {
+		if filepath.Base(event.Caller.File) != "instance_test.go" {
+			t.Errorf("%q: expected caller in instance_test.go, got %s", event.Message, event.Caller.File)
+		}
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	previous := Default()
+	defer SetDefault(previous)
+
+	logger := New()
+	sink, events := captureSink("default")
+	SetDefault(logger)
+
+	Hook(INFO, sink)
+	Info("through package")
+	Emit("CUSTOM", "not hooked")
+
+	got := events()
+	if len(got) != 1 {
+		t.Fatalf("expected 1 event through new default, got %d", len(got))
+	}
+	if filepath.Base(got[0].Caller.File) != "instance_test.go" {
+		t.Errorf("expected caller in instance_test.go, got %s", got[0].Caller.File)
+	}
+
+	if Default().Logger != logger.Logger {
+		t.Error("Default should return the logger passed to SetDefault")
+	}
+
+	SetDefault(nil)
+	if Default().Logger != logger.Logger {
+		t.Error("SetDefault(nil) should be a no-op")
+	}
+}
+
+func TestFieldsLoggerWith(t *testing.T) {
+	logger := New()
+	sink, events := captureSink("with")
+	logger.HookAll(sink)
+
+	request := logger.With(String("request_id", "req-1"))
+	db := request.With(String("component", "db"))
+
+	request.Info("request")
+	db.Info("query", Int("rows", 3))
+	db.Info("no fields")
+	logger.Info("parent")
+
+	keys := func(event Log) string {
+		names := make([]string, len(event.Data))
+		for i, field := range event.Data {
+			names[i] = field.Key
+		}
+		return strings.Join(names, ",")
+	}
+
+	want := []string{"request_id", "request_id,component,rows", "request_id,component", ""}
+	got := events()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(got))
+	}
+	for i, event := range got {
+		if keys(event) != want[i] {
+			t.Errorf("%q: fields = %s, want %s", event.Message, keys(event), want[i])
+		}
+		if filepath.Base(event.Caller.File) != "instance_test.go" {
+			t.Errorf("%q: caller = %s, want instance_test.go", event.Message, event.Caller.File)
+		}
+	}
+}
+
+func TestEventData(t *testing.T) {
+	bound := []Field{String("a", "1"), String("b", "2")}
+	fields := []Field{Int("c", 3)}
+	stack := captureStack(0)
+
+	keys := func(data []Field) string {
+		names := make([]string, len(data))
+		for i, field := range data {
+			names[i] = field.Key
+		}
+		return strings.Join(names, ",")
+	}
+
+	if got := eventData(bound, fields, stack); keys(got) != "a,b,c,stack" || cap(got) != 4 {
+		t.Errorf("joined data = %s with capacity %d", keys(got), cap(got))
+	}
+	if got := eventData(bound, nil, nil); keys(got) != "a,b" || cap(got) != len(bound) {
+		t.Errorf("bound-only data = %s with capacity %d", keys(got), cap(got))
+	}
+	if allocs := testing.AllocsPerRun(100, func() { eventData(bound, fields, stack) }); allocs != 1 {
+		t.Errorf("eventData allocated %.0f times, want 1", allocs)
+	}
+}
+
+func TestWithDoesNotShareBackingArray(t *testing.T) {
+	parent := New().With(String("a", "1"), String("b", "2"))
+	first := parent.With(String("c", "3"))
+	second := parent.With(String("d", "4"))
+
+	if first.fields[2].Key != "c" || second.fields[2].Key != "d" {
+		t.Errorf("sibling children share bound fields: %v / %v", first.fields, second.fields)
+	}
+}
+
+func TestPackageWith(t *testing.T) {
+	original := defaultLogger
+	defaultLogger = NewLogger[Fields]()
+	t.Cleanup(func() { defaultLogger = original })
+
+	sink, events := captureSink("package-with")
+	HookAll(sink)
+
+	With(String("service", "api")).Warn("bound")
+
+	got := events()
+	if len(got) != 1 || len(got[0].Data) != 1 || got[0].Data[0].Key != "service" {
+		t.Errorf("unexpected events: %+v", got)
+	}
+}
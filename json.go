@@ -0,0 +1,776 @@
+package zlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// JSONEncoder formats events as one JSON object per line. The envelope
+// comes first, then the fields as top-level properties in the order they
+// were added:
+//
+//	{"time":"2023-10-20T15:04:05Z","signal":"INFO","message":"User logged in","caller":"auth.go:42","user_id":"123"}
+//
+// Groups and objects become nested objects, arrays become arrays, and
+// errors become {"message","type","chain"} objects. This is the default
+// format of the stderr, file and HTTP sinks.
+//
+// Collisions decides what happens to a field whose key is already taken,
+// by the envelope or an earlier field, so no value silently replaces
+// another:
+//
+//	zlog.Info("Sent", zlog.String("message", "hi"))
+//	// CollisionPrefix: {...,"message":"Sent",...,"fields.message":"hi"}
+//	// CollisionNest:   {...,"message":"Sent",...,"fields":{"message":"hi"}}
+//	// CollisionSuffix: {...,"message":"Sent",...,"message_2":"hi"}
+//
+// The encoder appends to the buffer directly and switches on the field
+// type, so the standard field types encode without reflection or
+// allocation. Data fields, and values whose type does not match their
+// field type, fall back to encoding/json. NaN and infinite floats, which
+// JSON cannot represent, are written as the strings "NaN", "+Inf" and
+// "-Inf".
+type JSONEncoder struct {
+	Collisions CollisionPolicy
+}
+
+// CollisionPolicy is how JSONEncoder writes fields whose key is already
+// taken.
+type CollisionPolicy int
+
+const (
+	// CollisionPrefix writes a colliding field as "fields.<key>", or with a
+	// numbered suffix like "fields.<key>_2" if that is taken too. This is
+	// the default.
+	CollisionPrefix CollisionPolicy = iota
+
+	// CollisionNest writes all fields inside a "fields" object, so they
+	// never collide with the envelope. Repeated field keys get numbered
+	// suffixes like CollisionSuffix.
+	CollisionNest
+
+	// CollisionSuffix writes a colliding field as "<key>_2", "<key>_3" and
+	// so on.
+	CollisionSuffix
+)
+
+// jsonEnvelopeKeys are the properties JSONEncoder writes before the fields.
+// The caller key is reserved even for events without a caller, so a field's
+// key does not depend on whether the caller was captured.
+var jsonEnvelopeKeys = []string{"time", "signal", "message", "caller"}
+
+// Encode implements Encoder.
+func (e JSONEncoder) Encode(event Log, buf *Buffer) error {
+	dst := append(buf.bytes, `{"time":"`...)
+	dst = event.Time.AppendFormat(dst, time.RFC3339Nano)
+	dst = append(dst, `","signal":`...)
+	dst = appendJSONString(dst, string(event.Signal))
+	dst = append(dst, `,"message":`...)
+	dst = appendJSONString(dst, event.Message)
+
+	// Format caller as "file.go:42" for clean output
+	if caller := event.Caller.Resolve(); caller.File != "" {
+		dst = append(dst, `,"caller":"`...)
+		dst = appendJSONStringContent(dst, caller.File)
+		dst = append(dst, ':')
+		dst = strconv.AppendInt(dst, int64(caller.Line), 10)
+		dst = append(dst, '"')
+	}
+
+	var err error
+	switch {
+	case e.Collisions == CollisionNest && len(event.Data) > 0:
+		dst = append(dst, `,"fields":{`...)
+		dst, err = appendJSONFields(dst, event.Data, uniqueKeys(event.Data, nil, CollisionSuffix), false)
+		dst = append(dst, '}')
+	case e.Collisions != CollisionNest:
+		dst, err = appendJSONFields(dst, event.Data, uniqueKeys(event.Data, jsonEnvelopeKeys, e.Collisions), true)
+	}
+	if err != nil {
+		return err
+	}
+	buf.bytes = append(dst, '}', '\n')
+	return nil
+}
+
+// ContentType returns the MIME type of the encoded entries.
+func (JSONEncoder) ContentType() string {
+	return "application/json"
+}
+
+// appendJSONFields appends fields as "key":value pairs, using keys[i] as the
+// key of fields[i] when keys is not nil. With more set, the first pair is
+// preceded by a comma.
+func appendJSONFields(dst []byte, fields []Field, keys []string, more bool) ([]byte, error) {
+	var err error
+	for i, field := range fields {
+		if more {
+			dst = append(dst, ',')
+		}
+		more = true
+		key := field.Key
+		if keys != nil {
+			key = keys[i]
+		}
+		dst = appendJSONString(dst, key)
+		dst = append(dst, ':')
+		if dst, err = appendJSONFieldValue(dst, field); err != nil {
+			return dst, err
+		}
+	}
+	return dst, nil
+}
+
+// maxScannedKeys is the most fields whose keys are compared pairwise when
+// looking for collisions. Beyond it a map is cheaper.
+const maxScannedKeys = 32
+
+// uniqueKeys returns the keys to write fields under so that none repeats a
+// reserved key or an earlier field's key, renaming collisions by policy. A
+// new name never takes another field's own key, so only fields that really
+// collide are renamed. It returns nil when every field can keep its own key,
+// which is the common case and costs no allocation.
+func uniqueKeys(fields []Field, reserved []string, policy CollisionPolicy) []string {
+	if len(fields) <= maxScannedKeys && !hasDuplicateKeys(fields, reserved) {
+		return nil
+	}
+
+	// Every field's own key is off limits to new names
+	own := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		own[field.Key] = true
+	}
+	used := make(map[string]bool, len(reserved)+len(fields))
+	for _, key := range reserved {
+		used[key] = true
+	}
+
+	var keys []string
+	for i, field := range fields {
+		key := field.Key
+		if used[key] {
+			if keys == nil {
+				keys = make([]string, len(fields))
+				for j := 0; j < i; j++ {
+					keys[j] = fields[j].Key
+				}
+			}
+			base := key
+			if policy == CollisionPrefix {
+				base = "fields." + key
+			}
+			key = base
+			for n := 2; used[key] || own[key]; n++ {
+				key = base + "_" + strconv.Itoa(n)
+			}
+		}
+		used[key] = true
+		if keys != nil {
+			keys[i] = key
+		}
+	}
+	return keys
+}
+
+// hasDuplicateKeys reports whether any field repeats a reserved key or an
+// earlier field's key.
+func hasDuplicateKeys(fields []Field, reserved []string) bool {
+	for i, field := range fields {
+		for _, key := range reserved {
+			if field.Key == key {
+				return true
+			}
+		}
+		for _, earlier := range fields[:i] {
+			if field.Key == earlier.Key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// appendJSONFieldValue appends the JSON value of a field, switching on its
+// type.
+func appendJSONFieldValue(dst []byte, field Field) ([]byte, error) {
+	switch field.Type {
+	case StringType, ByteStringType:
+		if value, ok := field.Value.(string); ok {
+			return appendJSONString(dst, value), nil
+		}
+	case IntType:
+		if value, ok := field.Value.(int); ok {
+			return strconv.AppendInt(dst, int64(value), 10), nil
+		}
+	case Int64Type:
+		if value, ok := field.Value.(int64); ok {
+			return strconv.AppendInt(dst, value, 10), nil
+		}
+	case Int32Type:
+		if value, ok := field.Value.(int32); ok {
+			return strconv.AppendInt(dst, int64(value), 10), nil
+		}
+	case UintType:
+		if value, ok := field.Value.(uint); ok {
+			return strconv.AppendUint(dst, uint64(value), 10), nil
+		}
+	case Uint64Type:
+		if value, ok := field.Value.(uint64); ok {
+			return strconv.AppendUint(dst, value, 10), nil
+		}
+	case Float64Type:
+		if value, ok := field.Value.(float64); ok {
+			return appendJSONFloat(dst, value, 64), nil
+		}
+	case Float32Type:
+		if value, ok := field.Value.(float32); ok {
+			return appendJSONFloat(dst, float64(value), 32), nil
+		}
+	case BoolType:
+		if value, ok := field.Value.(bool); ok {
+			return strconv.AppendBool(dst, value), nil
+		}
+	case DurationType:
+		if value, ok := field.Value.(time.Duration); ok {
+			return strconv.AppendInt(dst, int64(value), 10), nil
+		}
+	case TimeType:
+		if value, ok := field.Value.(time.Time); ok {
+			return appendJSONTime(dst, value), nil
+		}
+	case StringsType:
+		if values, ok := field.Value.([]string); ok {
+			return appendJSONArray(dst, values, appendJSONString), nil
+		}
+	case IntsType:
+		if values, ok := field.Value.([]int); ok {
+			return appendJSONArray(dst, values, func(dst []byte, value int) []byte {
+				return strconv.AppendInt(dst, int64(value), 10)
+			}), nil
+		}
+	case Float64sType:
+		if values, ok := field.Value.([]float64); ok {
+			return appendJSONArray(dst, values, func(dst []byte, value float64) []byte {
+				return appendJSONFloat(dst, value, 64)
+			}), nil
+		}
+	case BoolsType:
+		if values, ok := field.Value.([]bool); ok {
+			return appendJSONArray(dst, values, strconv.AppendBool), nil
+		}
+	case TimesType:
+		if values, ok := field.Value.([]time.Time); ok {
+			return appendJSONArray(dst, values, appendJSONTime), nil
+		}
+	case DurationsType:
+		if values, ok := field.Value.([]time.Duration); ok {
+			return appendJSONArray(dst, values, func(dst []byte, value time.Duration) []byte {
+				return strconv.AppendInt(dst, int64(value), 10)
+			}), nil
+		}
+	case StackType:
+		if stack, ok := field.Resolve().Value.(StackTrace); ok {
+			return appendJSONArray(dst, stack, appendJSONFrame), nil
+		}
+	case GroupType:
+		if members, ok := field.Value.(Fields); ok {
+			dst = append(dst, '{')
+			dst, err := appendJSONFields(dst, members, uniqueKeys(members, nil, CollisionSuffix), false)
+			return append(dst, '}'), err
+		}
+	case ObjectType:
+		if value, ok := field.Value.(ObjectMarshaler); ok {
+			enc := getJSONObjectEncoder(dst)
+			defer putJSONObjectEncoder(enc)
+			enc.appendObject(value)
+			return enc.dst, enc.err
+		}
+	case ArrayType:
+		if value, ok := field.Value.(ArrayMarshaler); ok {
+			enc := getJSONObjectEncoder(dst)
+			defer putJSONObjectEncoder(enc)
+			enc.appendArray(value)
+			return enc.dst, enc.err
+		}
+	case LazyType:
+		return appendJSONFieldValue(dst, field.Resolve())
+	}
+	return appendJSONAny(dst, field.Value)
+}
+
+// appendJSONAny appends an arbitrary value, using encoding/json for types
+// it does not know.
+func appendJSONAny(dst []byte, value any) ([]byte, error) {
+	switch value := value.(type) {
+	case nil:
+		return append(dst, "null"...), nil
+	case string:
+		return appendJSONString(dst, value), nil
+	case bool:
+		return strconv.AppendBool(dst, value), nil
+	case int:
+		return strconv.AppendInt(dst, int64(value), 10), nil
+	case int64:
+		return strconv.AppendInt(dst, value, 10), nil
+	case float64:
+		return appendJSONFloat(dst, value, 64), nil
+	case time.Time:
+		return appendJSONTime(dst, value), nil
+	case errorRecord:
+		return appendErrorRecord(dst, value)
+	case error:
+		return appendErrorRecord(dst, newErrorRecord(value))
+	case []interface{}:
+		var err error
+		dst = append(dst, '[')
+		for i, element := range value {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			if dst, err = appendJSONAny(dst, element); err != nil {
+				return dst, err
+			}
+		}
+		return append(dst, ']'), nil
+	case map[string]interface{}:
+		// Maps have no order of their own, so sort like encoding/json
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var err error
+		dst = append(dst, '{')
+		for i, key := range keys {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendJSONString(dst, key)
+			dst = append(dst, ':')
+			if dst, err = appendJSONAny(dst, value[key]); err != nil {
+				return dst, err
+			}
+		}
+		return append(dst, '}'), nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return dst, fmt.Errorf("failed to marshal event to JSON: %w", err)
+	}
+	return append(dst, data...), nil
+}
+
+// appendErrorRecord appends the JSON form of an error.
+func appendErrorRecord(dst []byte, record errorRecord) ([]byte, error) {
+	dst = append(dst, `{"message":`...)
+	dst = appendJSONString(dst, record.Message)
+	dst = append(dst, `,"type":`...)
+	dst = appendJSONString(dst, record.Type)
+	if len(record.Chain) > 0 {
+		dst = append(dst, `,"chain":`...)
+		dst = appendJSONArray(dst, record.Chain, appendJSONString)
+	}
+
+	var err error
+	if len(record.Errors) > 0 {
+		dst = append(dst, `,"errors":[`...)
+		for i, inner := range record.Errors {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			if dst, err = appendErrorRecord(dst, inner); err != nil {
+				return dst, err
+			}
+		}
+		dst = append(dst, ']')
+	}
+	if len(record.Fields) > 0 {
+		dst = append(dst, `,"fields":`...)
+		if dst, err = appendJSONAny(dst, record.Fields); err != nil {
+			return dst, err
+		}
+	}
+	if len(record.Stack) > 0 {
+		dst = append(dst, `,"stack":`...)
+		dst = appendJSONArray(dst, record.Stack, appendJSONFrame)
+	}
+	return append(dst, '}'), nil
+}
+
+// MarshalJSON encodes the record the way JSONEncoder writes it, so every
+// JSON sink renders errors the same.
+func (r errorRecord) MarshalJSON() ([]byte, error) {
+	return appendErrorRecord(nil, r)
+}
+
+// appendJSONArray appends values as a JSON array. A nil slice is null, as
+// with encoding/json.
+func appendJSONArray[V any](dst []byte, values []V, appendValue func([]byte, V) []byte) []byte {
+	if values == nil {
+		return append(dst, "null"...)
+	}
+	dst = append(dst, '[')
+	for i, value := range values {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = appendValue(dst, value)
+	}
+	return append(dst, ']')
+}
+
+// appendJSONFrame appends a stack frame as an object.
+func appendJSONFrame(dst []byte, frame Frame) []byte {
+	dst = append(dst, `{"function":`...)
+	dst = appendJSONString(dst, frame.Function)
+	dst = append(dst, `,"file":`...)
+	dst = appendJSONString(dst, frame.File)
+	dst = append(dst, `,"line":`...)
+	dst = strconv.AppendInt(dst, int64(frame.Line), 10)
+	return append(dst, '}')
+}
+
+// appendJSONTime appends a time as an RFC 3339 string, as time.Time's
+// MarshalJSON does.
+func appendJSONTime(dst []byte, t time.Time) []byte {
+	dst = append(dst, '"')
+	dst = t.AppendFormat(dst, time.RFC3339Nano)
+	return append(dst, '"')
+}
+
+// appendJSONFloat appends a float the way encoding/json formats it. NaN and
+// infinities have no JSON form and are written as strings.
+func appendJSONFloat(dst []byte, f float64, bits int) []byte {
+	switch {
+	case math.IsNaN(f):
+		return append(dst, `"NaN"`...)
+	case math.IsInf(f, 1):
+		return append(dst, `"+Inf"`...)
+	case math.IsInf(f, -1):
+		return append(dst, `"-Inf"`...)
+	}
+
+	// Like encoding/json, use exponents only for very small or large values
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	dst = strconv.AppendFloat(dst, f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9
+		if n := len(dst); n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+	return dst
+}
+
+// appendJSONString appends s as a quoted JSON string.
+func appendJSONString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	dst = appendJSONStringContent(dst, s)
+	return append(dst, '"')
+}
+
+// hexDigits are used to escape control characters.
+const hexDigits = "0123456789abcdef"
+
+// appendJSONStringContent appends s escaped for use inside a JSON string.
+// Quotes, backslashes and control characters are escaped, invalid UTF-8 is
+// replaced with U+FFFD, and U+2028 and U+2029 are escaped so the output is
+// also valid JavaScript, as with encoding/json.
+func appendJSONStringContent(dst []byte, s string) []byte {
+	start := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				dst = append(dst, '\\', c)
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, `\ufffd`...)
+		case r == '\u2028' || r == '\u2029':
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hexDigits[r&0xf])
+		default:
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	return append(dst, s[start:]...)
+}
+
+// jsonObjectEncoder writes ObjectMarshaler and ArrayMarshaler values
+// straight into JSON. A marshaler that fails is replaced by its error, as in
+// the other sinks, so err only reports values encoding/json cannot write.
+type jsonObjectEncoder struct {
+	err   error
+	dst   []byte
+	empty bool // Nothing written yet in the current object or array
+}
+
+// jsonObjectEncoderPool reuses encoders, which escape to the heap when
+// handed to a marshaler.
+var jsonObjectEncoderPool = sync.Pool{
+	New: func() any {
+		return &jsonObjectEncoder{}
+	},
+}
+
+// getJSONObjectEncoder takes an encoder from the pool, set to append to dst.
+func getJSONObjectEncoder(dst []byte) *jsonObjectEncoder {
+	enc := jsonObjectEncoderPool.Get().(*jsonObjectEncoder) //nolint:errcheck // Only *jsonObjectEncoder values are pooled
+	enc.dst = dst
+	enc.err = nil
+	return enc
+}
+
+// putJSONObjectEncoder returns an encoder to the pool.
+func putJSONObjectEncoder(enc *jsonObjectEncoder) {
+	enc.dst = nil
+	enc.err = nil
+	jsonObjectEncoderPool.Put(enc)
+}
+
+// appendObject writes value as an object.
+func (e *jsonObjectEncoder) appendObject(value ObjectMarshaler) {
+	start := len(e.dst)
+	e.dst = append(e.dst, '{')
+	e.empty = true
+	err := value.MarshalLogObject(e)
+	e.dst = append(e.dst, '}')
+	e.empty = false
+	if err != nil {
+		e.replaceWithError(start, err)
+	}
+}
+
+// appendArray writes value as an array.
+func (e *jsonObjectEncoder) appendArray(value ArrayMarshaler) {
+	start := len(e.dst)
+	e.dst = append(e.dst, '[')
+	e.empty = true
+	err := value.MarshalLogArray(e)
+	e.dst = append(e.dst, ']')
+	e.empty = false
+	if err != nil {
+		e.replaceWithError(start, err)
+	}
+}
+
+// replaceWithError discards what was written from start and writes err.
+func (e *jsonObjectEncoder) replaceWithError(start int, err error) {
+	e.dst = e.dst[:start]
+	var marshalErr error
+	e.dst, marshalErr = appendErrorRecord(e.dst, newErrorRecord(err))
+	if e.err == nil {
+		e.err = marshalErr
+	}
+}
+
+// element starts the next object member or array element.
+func (e *jsonObjectEncoder) element() {
+	if !e.empty {
+		e.dst = append(e.dst, ',')
+	}
+	e.empty = false
+}
+
+// key starts an object member.
+func (e *jsonObjectEncoder) key(key string) {
+	e.element()
+	e.dst = appendJSONString(e.dst, key)
+	e.dst = append(e.dst, ':')
+}
+
+// AddString implements ObjectEncoder.
+func (e *jsonObjectEncoder) AddString(key, value string) {
+	e.key(key)
+	e.dst = appendJSONString(e.dst, value)
+}
+
+// AddInt implements ObjectEncoder.
+func (e *jsonObjectEncoder) AddInt(key string, value int) {
+	e.key(key)
+	e.dst = strconv.AppendInt(e.dst, int64(value), 10)
+}
+
+// AddInt64 implements ObjectEncoder.
+func (e *jsonObjectEncoder) AddInt64(key string, value int64) {
+	e.key(key)
+	e.dst = strconv.AppendInt(e.dst, value, 10)
+}
+
+// AddUint64 implements ObjectEncoder.
+func (e *jsonObjectEncoder) AddUint64(key string, value uint64) {
+	e.key(key)
+	e.dst = strconv.AppendUint(e.dst, value, 10)
+}
+
+// AddFloat64 implements ObjectEncoder.
+func (e *jsonObjectEncoder) AddFloat64(key string, value float64) {
+	e.key(key)
+	e.dst = appendJSONFloat(e.dst, value, 64)
+}
+
+// AddBool implements ObjectEncoder.
+func (e *jsonObjectEncoder) AddBool(key string, value bool) {
+	e.key(key)
+	e.dst = strconv.AppendBool(e.dst, value)
+}
+
+// AddDuration implements ObjectEncoder.
+func (e *jsonObjectEncoder) AddDuration(key string, value time.Duration) {
+	e.key(key)
+	e.dst = strconv.AppendInt(e.dst, int64(value), 10)
+}
+
+// AddTime implements ObjectEncoder.
+func (e *jsonObjectEncoder) AddTime(key string, value time.Time) {
+	e.key(key)
+	e.dst = appendJSONTime(e.dst, value)
+}
+
+// AddObject implements ObjectEncoder.
+func (e *jsonObjectEncoder) AddObject(key string, value ObjectMarshaler) error {
+	e.key(key)
+	e.appendObject(value)
+	return nil
+}
+
+// AddArray implements ObjectEncoder.
+func (e *jsonObjectEncoder) AddArray(key string, value ArrayMarshaler) error {
+	e.key(key)
+	e.appendArray(value)
+	return nil
+}
+
+// AppendString implements ArrayEncoder.
+func (e *jsonObjectEncoder) AppendString(value string) {
+	e.element()
+	e.dst = appendJSONString(e.dst, value)
+}
+
+// AppendInt implements ArrayEncoder.
+func (e *jsonObjectEncoder) AppendInt(value int) {
+	e.element()
+	e.dst = strconv.AppendInt(e.dst, int64(value), 10)
+}
+
+// AppendInt64 implements ArrayEncoder.
+func (e *jsonObjectEncoder) AppendInt64(value int64) {
+	e.element()
+	e.dst = strconv.AppendInt(e.dst, value, 10)
+}
+
+// AppendUint64 implements ArrayEncoder.
+func (e *jsonObjectEncoder) AppendUint64(value uint64) {
+	e.element()
+	e.dst = strconv.AppendUint(e.dst, value, 10)
+}
+
+// AppendFloat64 implements ArrayEncoder.
+func (e *jsonObjectEncoder) AppendFloat64(value float64) {
+	e.element()
+	e.dst = appendJSONFloat(e.dst, value, 64)
+}
+
+// AppendBool implements ArrayEncoder.
+func (e *jsonObjectEncoder) AppendBool(value bool) {
+	e.element()
+	e.dst = strconv.AppendBool(e.dst, value)
+}
+
+// AppendDuration implements ArrayEncoder.
+func (e *jsonObjectEncoder) AppendDuration(value time.Duration) {
+	e.element()
+	e.dst = strconv.AppendInt(e.dst, int64(value), 10)
+}
+
+// AppendTime implements ArrayEncoder.
+func (e *jsonObjectEncoder) AppendTime(value time.Time) {
+	e.element()
+	e.dst = appendJSONTime(e.dst, value)
+}
+
+// AppendObject implements ArrayEncoder.
+func (e *jsonObjectEncoder) AppendObject(value ObjectMarshaler) error {
+	e.element()
+	e.appendObject(value)
+	return nil
+}
+
+// AppendArray implements ArrayEncoder.
+func (e *jsonObjectEncoder) AppendArray(value ArrayMarshaler) error {
+	e.element()
+	e.appendArray(value)
+	return nil
+}
+
+// addJSONFields adds fields to a JSON entry as properties.
+func addJSONFields(entry map[string]interface{}, fields []Field) {
+	for _, field := range fields {
+		entry[field.Key] = jsonFieldValue(field)
+	}
+}
+
+// jsonFieldValue returns the value JSON sinks write for a field. Groups and
+// objects become nested objects, arrays become arrays, and errors become
+// {"message","type","chain"} objects.
+func jsonFieldValue(field Field) interface{} {
+	members, nested, err := nestedFields(field)
+	switch {
+	case err != nil:
+		return newErrorRecord(err)
+	case nested && field.Type == ArrayType:
+		values := make([]interface{}, len(members))
+		for i, member := range members {
+			values[i] = jsonFieldValue(member)
+		}
+		return values
+	case nested:
+		object := make(map[string]interface{}, len(members))
+		addJSONFields(object, members)
+		return object
+	}
+
+	if err, ok := field.Value.(error); ok {
+		return newErrorRecord(err)
+	}
+	return field.Value
+}
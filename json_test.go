@@ -0,0 +1,292 @@
+package zlog
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONEncoder(t *testing.T) {
+	event := testEvent("User logged in", String("user_id", "123"), Group("http", Int("status", 200)))
+
+	buf := &Buffer{}
+	if err := (JSONEncoder{}).Encode(event, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"time":"2024-03-01T12:00:00Z","signal":"INFO","message":"User logged in","caller":"auth.go:42","user_id":"123","http":{"status":200}}` + "\n"
+	if buf.String() != want {
+		t.Errorf("got  %s\nwant %s", buf.String(), want)
+	}
+}
+
+func TestJSONEncoderFieldOrder(t *testing.T) {
+	event := testEvent("x", String("zebra", "z"), String("apple", "a"), Int("mango", 1))
+
+	buf := &Buffer{}
+	if err := (JSONEncoder{}).Encode(event, buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(buf.String(), `"zebra":"z","apple":"a","mango":1}`+"\n") {
+		t.Errorf("fields not in emission order: %s", buf.String())
+	}
+}
+
+func TestJSONEncoderCollisions(t *testing.T) {
+	fields := []Field{
+		String("message", "from field"),
+		String("user", "alice"),
+		String("user", "bob"),
+		String("user", "carol"),
+		Group("g", Int("n", 1), Int("n", 2)),
+	}
+	envelope := `{"time":"2024-03-01T12:00:00Z","signal":"INFO","message":"x","caller":"auth.go:42",`
+
+	tests := []struct {
+		name   string
+		policy CollisionPolicy
+		want   string
+	}{
+		{
+			name:   "prefix",
+			policy: CollisionPrefix,
+			want:   `"fields.message":"from field","user":"alice","fields.user":"bob","fields.user_2":"carol","g":{"n":1,"n_2":2}}`,
+		},
+		{
+			name:   "nest",
+			policy: CollisionNest,
+			want:   `"fields":{"message":"from field","user":"alice","user_2":"bob","user_3":"carol","g":{"n":1,"n_2":2}}}`,
+		},
+		{
+			name:   "suffix",
+			policy: CollisionSuffix,
+			want:   `"message_2":"from field","user":"alice","user_2":"bob","user_3":"carol","g":{"n":1,"n_2":2}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &Buffer{}
+			if err := (JSONEncoder{Collisions: tt.policy}).Encode(testEvent("x", fields...), buf); err != nil {
+				t.Fatal(err)
+			}
+			if want := envelope + tt.want + "\n"; buf.String() != want {
+				t.Errorf("got  %s\nwant %s", buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestJSONEncoderCollisionsTakenNames(t *testing.T) {
+	// A renamed key must not collide with a field that already uses it
+	event := testEvent("x", String("fields.message", "a"), String("message", "b"), String("message_2", "c"), String("message", "d"))
+
+	buf := &Buffer{}
+	if err := (JSONEncoder{Collisions: CollisionSuffix}).Encode(event, buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `"fields.message":"a","message_3":"b","message_2":"c","message_4":"d"}` + "\n"
+	if !strings.HasSuffix(buf.String(), want) {
+		t.Errorf("got %s, want suffix %s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := (JSONEncoder{}).Encode(event, buf); err != nil {
+		t.Fatal(err)
+	}
+	want = `"fields.message":"a","fields.message_2":"b","message_2":"c","fields.message_3":"d"}` + "\n"
+	if !strings.HasSuffix(buf.String(), want) {
+		t.Errorf("got %s, want suffix %s", buf.String(), want)
+	}
+
+	// Only the field that collides is renamed, even when a later field
+	// already uses the name it would get
+	buf.Reset()
+	event = testEvent("x", String("message", "a"), String("message_2", "b"))
+	if err := (JSONEncoder{Collisions: CollisionSuffix}).Encode(event, buf); err != nil {
+		t.Fatal(err)
+	}
+	want = `"message_3":"a","message_2":"b"}` + "\n"
+	if !strings.HasSuffix(buf.String(), want) {
+		t.Errorf("got %s, want suffix %s", buf.String(), want)
+	}
+}
+
+func TestJSONEncoderNestWithoutFields(t *testing.T) {
+	buf := &Buffer{}
+	if err := (JSONEncoder{Collisions: CollisionNest}).Encode(testEvent("x"), buf); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "fields") {
+		t.Errorf("expected no fields object for an event without fields: %s", buf.String())
+	}
+}
+
+func TestJSONEncoderManyFields(t *testing.T) {
+	fields := make([]Field, 0, 2*maxScannedKeys)
+	for i := 0; i < 2*maxScannedKeys; i++ {
+		fields = append(fields, Int("k"+strconv.Itoa(i), i))
+	}
+	fields = append(fields, Int("k0", -1))
+
+	buf := &Buffer{}
+	if err := (JSONEncoder{}).Encode(testEvent("x", fields...), buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"k0":0,`) || !strings.HasSuffix(buf.String(), `"fields.k0":-1}`+"\n") {
+		t.Errorf("unexpected output %s", buf.String())
+	}
+}
+
+func TestJSONEncoderUnsupportedValue(t *testing.T) {
+	buf := &Buffer{}
+	if err := (JSONEncoder{}).Encode(testEvent("x", Data("ch", make(chan int))), buf); err == nil {
+		t.Error("expected an error for a value encoding/json cannot marshal")
+	}
+}
+
+func TestJSONEncoderStrings(t *testing.T) {
+	tests := []string{
+		"",
+		"plain",
+		`quote " and backslash \`,
+		"newline\n tab\t return\r",
+		"control \x00 \x01 \x1f \x7f",
+		"unicode é 日本 🚀",
+		"invalid \xff utf-8 \xc3",
+		"separators \u2028 \u2029",
+		"<html> & 'quotes'",
+	}
+
+	for _, s := range tests {
+		got := string(appendJSONString(nil, s))
+
+		var decoded string
+		if err := json.Unmarshal([]byte(got), &decoded); err != nil {
+			t.Errorf("%q encoded as invalid JSON %s: %v", s, got, err)
+			continue
+		}
+		want := strings.ToValidUTF8(s, "�")
+		if decoded != want {
+			t.Errorf("%q round-tripped as %q", s, decoded)
+		}
+	}
+
+	if got := string(appendJSONString(nil, "a\u2028<b>")); got != `"a\u2028<b>"` {
+		t.Errorf("got %s, want line separator escaped and HTML left alone", got)
+	}
+}
+
+func TestJSONEncoderFloats(t *testing.T) {
+	for _, f := range []float64{0, 1, -1.5, 0.1, 1e-7, 123456789, 1e20, 1e21, 1.5e300, -2.5e-10} {
+		want, err := json.Marshal(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(appendJSONFloat(nil, f, 64)); got != string(want) {
+			t.Errorf("%v: got %s, want %s", f, got, want)
+		}
+	}
+
+	for _, f := range []float32{0.1, 1e-7, 3.4e38} {
+		want, err := json.Marshal(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(appendJSONFloat(nil, float64(f), 32)); got != string(want) {
+			t.Errorf("%v: got %s, want %s", f, got, want)
+		}
+	}
+
+	special := map[float64]string{math.NaN(): `"NaN"`, math.Inf(1): `"+Inf"`, math.Inf(-1): `"-Inf"`}
+	for f, want := range special {
+		if got := string(appendJSONFloat(nil, f, 64)); got != want {
+			t.Errorf("%v: got %s, want %s", f, got, want)
+		}
+	}
+}
+
+// TestJSONEncoderMatchesMap checks that the encoder writes the same values
+// as the map-based rendering the other JSON paths use.
+func TestJSONEncoderMatchesMap(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 0, 0, 5, time.UTC)
+	user := testUser{id: "u1", roles: testRoles{"admin"}, age: 36}
+	fields := []Field{
+		String("s", "x"),
+		Int("i", -1),
+		Int64("i64", 1<<40),
+		Int32("i32", 7),
+		Uint("u", 8),
+		Uint64("u64", math.MaxUint64),
+		Float64("f", 0.25),
+		Float32("f32", 0.1),
+		Bool("b", true),
+		Duration("d", time.Second),
+		Time("t", at),
+		Strings("ss", []string{"a", "b"}),
+		Strings("nil", nil),
+		Ints("is", []int{1, 2}),
+		Float64s("fs", []float64{1.5}),
+		Bools("bs", []bool{false}),
+		Times("ts", []time.Time{at}),
+		Durations("ds", []time.Duration{time.Millisecond}),
+		Group("g", String("inner", "v"), Group("deeper", Int("n", 1))),
+		Object("user", user),
+		Array("team", testTeam{user}),
+		Object("broken", failingObject{}),
+		Err(errors.New("boom")),
+		Data("data", map[string]int{"b": 2, "a": 1}),
+		Any("any", []interface{}{"x", 1.5}),
+	}
+
+	buf := &Buffer{}
+	if err := (JSONEncoder{}).Encode(testEvent("x", fields...), buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var got, want map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %s: %v", buf.String(), err)
+	}
+	if err := json.Unmarshal([]byte(renderJSON(t, fields...)), &want); err != nil {
+		t.Fatal(err)
+	}
+
+	for key, value := range want {
+		gotJSON, _ := json.Marshal(got[key]) //nolint:errcheck // Decoded values always marshal
+		wantJSON, _ := json.Marshal(value)   //nolint:errcheck // Decoded values always marshal
+		if string(gotJSON) != string(wantJSON) {
+			t.Errorf("%s: got %s, want %s", key, gotJSON, wantJSON)
+		}
+	}
+}
+
+func TestJSONEncoderAllocations(t *testing.T) {
+	event := testEvent("request handled",
+		String("method", "GET"),
+		Int("status", 200),
+		Int64("bytes", 5120),
+		Float64("ratio", 0.75),
+		Bool("cached", true),
+		Duration("latency", 42*time.Millisecond),
+		Time("started", time.Date(2024, 3, 1, 11, 59, 59, 0, time.UTC)),
+		Strings("tags", []string{"api", "v2"}),
+		Group("http", String("path", "/users"), Int("status", 200)),
+	)
+
+	buf := &Buffer{}
+	encoder := JSONEncoder{}
+	allocs := testing.AllocsPerRun(100, func() {
+		buf.Reset()
+		if err := encoder.Encode(event, buf); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs > 0 {
+		t.Errorf("Encode allocated %.0f times per event, want 0", allocs)
+	}
+}
@@ -0,0 +1,116 @@
+package zlog
+
+import (
+	"sync"
+)
+
+// lazyValue computes a field value at most once, on first use.
+type lazyValue struct {
+	compute func() any
+	value   any
+	typ     FieldType
+	once    sync.Once
+}
+
+// get returns the computed value, computing it on the first call.
+func (v *lazyValue) get() any {
+	v.once.Do(func() {
+		v.value = v.compute()
+		v.compute = nil
+	})
+	return v.value
+}
+
+// Lazy creates a field whose value is computed only if the event reaches a
+// sink. Use it for values that are expensive to build:
+//
+//	zlog.Debug("Request received",
+//	    zlog.Lazy("body", func() any { return dumpRequest(r) }))
+//
+// The function runs at most once, however many sinks receive the event, and
+// never if the signal has no route. It may run on another goroutine, after
+// the logging call returns, so it must not depend on state that changes in
+// the meantime. The resolved field has type DataType.
+func Lazy(key string, compute func() any) Field {
+	return newLazy(key, DataType, compute)
+}
+
+// LazyString creates a string field computed only if the event reaches a sink.
+//
+//	zlog.LazyString("checksum", func() string { return sha256Hex(payload) })
+func LazyString(key string, compute func() string) Field {
+	return newLazy(key, StringType, func() any { return compute() })
+}
+
+// LazyInt creates an int field computed only if the event reaches a sink.
+//
+//	zlog.LazyInt("queue_depth", queue.Len)
+func LazyInt(key string, compute func() int) Field {
+	return newLazy(key, IntType, func() any { return compute() })
+}
+
+// newLazy creates a LazyType field that resolves to a field of type typ.
+func newLazy(key string, typ FieldType, compute func() any) Field {
+	return Field{Key: key, Type: LazyType, Value: &lazyValue{compute: compute, typ: typ}}
+}
+
+// Resolve returns the field with a lazy value computed, including lazy
+// fields nested in a Group. A StackType field gets its frames looked up, so
+// its value is a StackTrace. Other fields are returned unchanged.
+//
+// Sinks created with NewSink receive resolved LazyType fields. Hooks attached
+// to a Logger directly see them unresolved and can call Resolve themselves.
+// Stack traces, like CallerInfo, are only resolved by the encoders that
+// print them; custom sinks reading one call Resolve first.
+func (f Field) Resolve() Field {
+	switch f.Type {
+	case LazyType:
+		if lazy, ok := f.Value.(*lazyValue); ok {
+			return Field{Key: f.Key, Type: lazy.typ, Value: lazy.get()}
+		}
+	case StackType:
+		if stack, ok := f.Value.(*lazyStack); ok {
+			return Field{Key: f.Key, Type: StackType, Value: stack.resolve()}
+		}
+	case GroupType:
+		if members, ok := f.Value.(Fields); ok && hasLazy(members) {
+			resolved := make(Fields, len(members))
+			for i, member := range members {
+				resolved[i] = member.Resolve()
+			}
+			return Field{Key: f.Key, Type: GroupType, Value: resolved}
+		}
+	}
+	return f
+}
+
+// hasLazy reports whether any field, or any field nested in a group, is lazy.
+func hasLazy(fields []Field) bool {
+	for _, field := range fields {
+		switch field.Type {
+		case LazyType:
+			return true
+		case GroupType:
+			if members, ok := field.Value.(Fields); ok && hasLazy(members) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolveLazy returns the event with every lazy field computed. Events
+// without lazy fields are returned as they are, without copying.
+func resolveLazy(event Log) Log {
+	if !hasLazy(event.Data) {
+		return event
+	}
+
+	// Copy so other sinks sharing the slice keep their own view
+	data := make(Fields, len(event.Data))
+	for i, field := range event.Data {
+		data[i] = field.Resolve()
+	}
+	event.Data = data
+	return event
+}
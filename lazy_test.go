@@ -0,0 +1,103 @@
+package zlog
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLazyFields(t *testing.T) {
+	logger := New()
+
+	var mu sync.Mutex
+	var received []Field
+	record := func(_ context.Context, event Log) error {
+		mu.Lock()
+		received = append(received, event.Data...)
+		mu.Unlock()
+		return nil
+	}
+	logger.Hook(INFO, NewSink("first", record), NewSink("second", record))
+
+	var calls atomic.Int32
+	logger.Info("computed",
+		Lazy("payload", func() any { calls.Add(1); return map[string]int{"a": 1} }),
+		LazyString("hash", func() string { calls.Add(1); return "abc" }),
+		LazyInt("depth", func() int { calls.Add(1); return 7 }))
+
+	if err := logger.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected each lazy field computed once, got %d calls", got)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 6 {
+		t.Fatalf("expected 6 fields across both sinks, got %d", len(received))
+	}
+	for _, field := range received {
+		switch field.Key {
+		case "payload":
+			if field.Type != DataType {
+				t.Errorf("payload type = %s", field.Type)
+			}
+		case "hash":
+			if field.Type != StringType || field.Value != "abc" {
+				t.Errorf("hash = %+v", field)
+			}
+		case "depth":
+			if field.Type != IntType || field.Value != 7 {
+				t.Errorf("depth = %+v", field)
+			}
+		}
+	}
+}
+
+func TestLazyFieldsWithoutRoute(t *testing.T) {
+	logger := New()
+	logger.Hook(ERROR, NewSink("errors", func(_ context.Context, _ Log) error { return nil }))
+
+	called := false
+	logger.Debug("unrouted", Lazy("expensive", func() any { called = true; return nil }))
+
+	if called {
+		t.Error("lazy field computed for a signal without a route")
+	}
+}
+
+func TestFieldResolve(t *testing.T) {
+	plain := String("key", "value")
+	if plain.Resolve() != plain {
+		t.Error("Resolve changed a non-lazy field")
+	}
+
+	lazy := LazyString("key", func() string { return "value" })
+	if lazy.Type != LazyType {
+		t.Fatalf("lazy field type = %s", lazy.Type)
+	}
+	if resolved := lazy.Resolve(); resolved != plain {
+		t.Errorf("Resolve() = %+v, want %+v", resolved, plain)
+	}
+}
+
+func TestResolveLazyKeepsOriginal(t *testing.T) {
+	fields := Fields{String("a", "1"), LazyInt("b", func() int { return 2 })}
+	event := NewEvent(INFO, "msg", fields)
+
+	resolved := resolveLazy(event)
+	if resolved.Data[1].Type != IntType {
+		t.Errorf("field not resolved: %+v", resolved.Data[1])
+	}
+	if fields[1].Type != LazyType {
+		t.Error("resolveLazy modified the caller's fields")
+	}
+
+	plain := NewEvent(INFO, "msg", Fields{String("a", "1")})
+	if &resolveLazy(plain).Data[0] != &plain.Data[0] {
+		t.Error("events without lazy fields should not be copied")
+	}
+}
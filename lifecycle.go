@@ -0,0 +1,231 @@
+package zlog
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/zoobzio/pipz"
+)
+
+// fatalShutdownTimeout bounds how long Fatal waits for sinks before exiting.
+const fatalShutdownTimeout = 5 * time.Second
+
+// Flusher is implemented by sinks and hooks that buffer events.
+//
+// Flush should write out anything that has been accepted but not yet
+// delivered, returning early if the context is canceled. *Sink implements
+// Flusher, and any custom pipz.Chainable hooked to a logger can implement it
+// to participate in Shutdown.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// Closer is implemented by sinks and hooks that hold resources such as files
+// or network connections.
+//
+// Close is called once during Shutdown, after every sink has been flushed.
+// *Sink implements Closer, and any custom pipz.Chainable hooked to a logger
+// can implement it to participate in Shutdown.
+type Closer interface {
+	Close(ctx context.Context) error
+}
+
+// sinkResource is a flush and/or close action attached to a sink.
+//
+// Resources are shared by pointer between a sink and every sink wrapped
+// around it, so a resource reachable through several routes is only
+// flushed and closed once per Shutdown.
+type sinkResource struct {
+	flush     func(context.Context) error
+	close     func(context.Context) error
+	closeErr  error
+	closeOnce sync.Once
+}
+
+// runFlush invokes the flush action if there is one.
+func (r *sinkResource) runFlush(ctx context.Context) error {
+	if r.flush == nil {
+		return nil
+	}
+	return r.flush(ctx)
+}
+
+// runClose invokes the close action at most once.
+func (r *sinkResource) runClose(ctx context.Context) error {
+	if r.close == nil {
+		return nil
+	}
+	r.closeOnce.Do(func() {
+		r.closeErr = r.close(ctx)
+	})
+	return r.closeErr
+}
+
+// resourceOwner is implemented by hooks that carry sink resources.
+type resourceOwner interface {
+	sinkResources() []*sinkResource
+}
+
+// inflight tracks work that must finish before shutdown completes.
+// Unlike sync.WaitGroup it supports waiting with a context and refusing
+// new work once closed.
+type inflight struct {
+	waiters []chan struct{}
+	count   int
+	closed  bool
+	mu      sync.Mutex
+}
+
+// begin registers one unit of work, unless the tracker has been closed.
+func (f *inflight) begin() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return false
+	}
+	f.count++
+	return true
+}
+
+// add registers n units of work that were admitted by an earlier begin.
+func (f *inflight) add(n int) {
+	f.mu.Lock()
+	f.count += n
+	f.mu.Unlock()
+}
+
+// done marks one unit of work as finished.
+func (f *inflight) done() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.count--
+	if f.count == 0 {
+		for _, waiter := range f.waiters {
+			close(waiter)
+		}
+		f.waiters = nil
+	}
+}
+
+// close stops begin from admitting new work.
+func (f *inflight) close() {
+	f.mu.Lock()
+	f.closed = true
+	f.mu.Unlock()
+}
+
+// wait blocks until all registered work is done or the context ends.
+func (f *inflight) wait(ctx context.Context) error {
+	f.mu.Lock()
+	if f.count == 0 {
+		f.mu.Unlock()
+		return nil
+	}
+	waiter := make(chan struct{})
+	f.waiters = append(f.waiters, waiter)
+	f.mu.Unlock()
+
+	select {
+	case <-waiter:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// shutdownHooks flushes and then closes every hook, deduplicating shared
+// sink resources. Outer resources are flushed first so that async wrappers
+// drain into the sinks they feed before those sinks are closed.
+func shutdownHooks[T any](ctx context.Context, hooks []pipz.Chainable[T]) error {
+	var resources []*sinkResource
+	var others []pipz.Chainable[T]
+	seen := make(map[*sinkResource]bool)
+
+	for _, hook := range hooks {
+		if owner, ok := hook.(resourceOwner); ok {
+			owned := owner.sinkResources()
+			for i := len(owned) - 1; i >= 0; i-- {
+				if !seen[owned[i]] {
+					seen[owned[i]] = true
+					resources = append(resources, owned[i])
+				}
+			}
+			continue
+		}
+		if !containsHook(others, hook) {
+			others = append(others, hook)
+		}
+	}
+
+	var errs []error
+	for _, resource := range resources {
+		if err := resource.runFlush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, hook := range others {
+		if flusher, ok := hook.(Flusher); ok {
+			if err := flusher.Flush(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	for _, resource := range resources {
+		if err := resource.runClose(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, hook := range others {
+		if closer, ok := hook.(Closer); ok {
+			if err := closer.Close(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// containsHook reports whether hook is already in hooks, by identity.
+func containsHook[T any](hooks []pipz.Chainable[T], hook pipz.Chainable[T]) bool {
+	for _, candidate := range hooks {
+		if sameHook(candidate, hook) {
+			return true
+		}
+	}
+	return false
+}
+
+// Shutdown gracefully stops the default logger.
+//
+// New events are rejected, in-flight work (including async sinks and
+// parallel hooks) is awaited, and every hooked sink is flushed and then
+// closed, followed by the SetDeadLetter sink. The context bounds how long
+// Shutdown waits:
+//
+//	func main() {
+//	    defer func() {
+//	        ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	        defer cancel()
+//	        if err := zlog.Shutdown(ctx); err != nil {
+//	            fmt.Fprintln(os.Stderr, "log shutdown:", err)
+//	        }
+//	    }()
+//
+//	    run()
+//	}
+//
+// Shutdown returns the context error if the deadline passes, joined with any
+// errors reported by sinks while flushing or closing.
+func Shutdown(ctx context.Context) error {
+	err := currentLogger().Shutdown(ctx)
+	if dlq := deadLetterSink.Load(); dlq != nil {
+		err = errors.Join(err, shutdownHooks(ctx, []pipz.Chainable[Log]{dlq}))
+	}
+	return err
+}
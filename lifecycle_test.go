@@ -0,0 +1,204 @@
+package zlog
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLoggerShutdownWaitsForAsyncSinks(t *testing.T) {
+	logger := NewLogger[Fields]()
+
+	var processed int64
+	slowSink := NewSink("slow", func(_ context.Context, _ Log) error {
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt64(&processed, 1)
+		return nil
+	}).WithAsync()
+
+	logger.Hook("SHUTDOWN_TEST", slowSink)
+	for i := 0; i < 5; i++ {
+		logger.Process(NewEvent("SHUTDOWN_TEST", "slow event", nil))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := logger.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+
+	if got := atomic.LoadInt64(&processed); got != 5 {
+		t.Errorf("expected 5 events processed before shutdown returned, got %d", got)
+	}
+}
+
+func TestLoggerShutdownWaitsForScaffoldHooks(t *testing.T) {
+	logger := NewLogger[Fields]()
+
+	var processed int64
+	handler := func(_ context.Context, _ Log) error {
+		time.Sleep(30 * time.Millisecond)
+		atomic.AddInt64(&processed, 1)
+		return nil
+	}
+	logger.Hook("SCAFFOLD_SHUTDOWN", NewSink("a", handler), NewSink("b", handler))
+
+	logger.Process(NewEvent("SCAFFOLD_SHUTDOWN", "parallel", nil))
+
+	if err := logger.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+
+	if got := atomic.LoadInt64(&processed); got != 2 {
+		t.Errorf("expected both hooks to finish, got %d", got)
+	}
+}
+
+func TestLoggerShutdownRejectsNewEvents(t *testing.T) {
+	logger := NewLogger[Fields]()
+
+	var processed int64
+	logger.Hook("AFTER_SHUTDOWN", NewSink("counter", func(_ context.Context, _ Log) error {
+		atomic.AddInt64(&processed, 1)
+		return nil
+	}))
+
+	if err := logger.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+
+	logger.Process(NewEvent("AFTER_SHUTDOWN", "dropped", nil))
+	if got := atomic.LoadInt64(&processed); got != 0 {
+		t.Errorf("expected events after shutdown to be dropped, got %d", got)
+	}
+}
+
+func TestLoggerShutdownDeadline(t *testing.T) {
+	logger := NewLogger[Fields]()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	logger.Hook("STUCK", NewSink("stuck", func(_ context.Context, _ Log) error {
+		<-release
+		return nil
+	}).WithAsync())
+	logger.Process(NewEvent("STUCK", "never finishes", nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := logger.Shutdown(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestLoggerShutdownFlushesAndClosesOnce(t *testing.T) {
+	logger := NewLogger[Fields]()
+
+	var flushes, closes int64
+	base := NewSink("resource", func(_ context.Context, _ Log) error { return nil }).
+		WithFlush(func(_ context.Context) error {
+			atomic.AddInt64(&flushes, 1)
+			return nil
+		}).
+		WithClose(func(_ context.Context) error {
+			atomic.AddInt64(&closes, 1)
+			return nil
+		})
+
+	// The same underlying resources are reachable through several routes
+	logger.Hook(INFO, base)
+	logger.Hook(ERROR, base.WithRetry(3))
+	logger.HookAll(base.WithTimeout(time.Second))
+
+	if err := logger.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+
+	if got := atomic.LoadInt64(&flushes); got != 1 {
+		t.Errorf("expected 1 flush, got %d", got)
+	}
+	if got := atomic.LoadInt64(&closes); got != 1 {
+		t.Errorf("expected 1 close, got %d", got)
+	}
+}
+
+func TestLoggerShutdownReportsSinkErrors(t *testing.T) {
+	logger := NewLogger[Fields]()
+
+	closeErr := errors.New("close failed")
+	logger.Hook(INFO, NewSink("failing", func(_ context.Context, _ Log) error { return nil }).
+		WithClose(func(_ context.Context) error { return closeErr }))
+
+	if err := logger.Shutdown(context.Background()); !errors.Is(err, closeErr) {
+		t.Errorf("expected close error, got %v", err)
+	}
+}
+
+func TestSinkCloseThroughFallback(t *testing.T) {
+	var primaryClosed, backupClosed bool
+	primary := NewSink("primary", func(_ context.Context, _ Log) error { return nil }).
+		WithClose(func(_ context.Context) error {
+			primaryClosed = true
+			return nil
+		})
+	backup := NewSink("backup", func(_ context.Context, _ Log) error { return nil }).
+		WithClose(func(_ context.Context) error {
+			backupClosed = true
+			return nil
+		})
+
+	if err := primary.WithFallback(backup).Close(context.Background()); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+
+	if !primaryClosed || !backupClosed {
+		t.Errorf("expected both sinks closed, primary=%v backup=%v", primaryClosed, backupClosed)
+	}
+}
+
+func TestShutdownClosesRotatingFile(t *testing.T) {
+	defer func() { defaultLogger = NewLogger[Fields]() }()
+	defaultLogger = NewLogger[Fields]()
+
+	filename := filepath.Join(t.TempDir(), "shutdown.log")
+	sink := NewRotatingFileSink(filename, 0, 0).WithAsync()
+	Hook(INFO, sink)
+
+	for i := 0; i < 10; i++ {
+		Info("before shutdown", Int("i", i))
+	}
+
+	if err := Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	lines := 0
+	for _, b := range data {
+		if b == '\n' {
+			lines++
+		}
+	}
+	if lines != 10 {
+		t.Errorf("expected 10 lines written before shutdown, got %d", lines)
+	}
+
+	// The file is closed, so direct writes now fail
+	if _, err := sink.Process(context.Background(), NewEvent(INFO, "late", nil)); err != nil {
+		t.Fatalf("async sink should not report errors: %v", err)
+	}
+	if err := sink.Close(context.Background()); err != nil {
+		t.Errorf("closing an already closed sink should succeed: %v", err)
+	}
+}
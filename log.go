@@ -2,10 +2,13 @@ package zlog
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
-	"time"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 // log.go - Standard logging module for terminal output.
@@ -20,37 +23,14 @@ import (
 
 // stderrJSONSink outputs JSON-formatted logs to stderr.
 //
-// This sink demonstrates a typical JSON formatter that includes all event
-// fields in a flat structure. The format is compatible with most log
-// aggregation systems (ELK, Datadog, CloudWatch, etc.).
+// It writes with JSONEncoder, which includes all event fields in a flat
+// structure. The format is compatible with most log aggregation systems
+// (ELK, Datadog, CloudWatch, etc.).
 //
 // Output format:
 //
 //	{"time":"2023-10-20T15:04:05Z","signal":"INFO","message":"User logged in","caller":"auth.go:42","user_id":"123"}
-var stderrJSONSink = NewSink("stderr-json", func(_ context.Context, event Log) error {
-	// Build JSON structure
-	entry := map[string]interface{}{
-		"time":    event.Time.Format(time.RFC3339Nano),
-		"signal":  string(event.Signal),
-		"message": event.Message,
-	}
-
-	// Add caller info if available.
-	// We use basename:line format for readability in terminals.
-	if event.Caller.File != "" {
-		// Format as "file.go:42" for clean output
-		entry["caller"] = fmt.Sprintf("%s:%d", event.Caller.File, event.Caller.Line)
-	}
-
-	// Add all structured fields as top-level JSON properties.
-	// This flattens the structure but makes fields easily searchable.
-	for _, field := range event.Data {
-		entry[field.Key] = field.Value
-	}
-
-	encoder := json.NewEncoder(os.Stderr)
-	return encoder.Encode(entry)
-})
+var stderrJSONSink = newWriterSink("stderr-json", stderrWriter{}, JSONEncoder{})
 
 // ConsoleJSONSink outputs JSON-formatted logs to stdout/stderr for ALL signals.
 //
@@ -59,6 +39,7 @@ var stderrJSONSink = NewSink("stderr-json", func(_ context.Context, event Log) e
 // environments where you want complete visibility into all events.
 //
 // By default, it writes to stderr. Pass true for stdout to write there instead.
+// Events are written as JSON unless WithEncoder picks another format.
 //
 // Usage:
 //
@@ -69,7 +50,10 @@ var stderrJSONSink = NewSink("stderr-json", func(_ context.Context, event Log) e
 //
 //	// Or to stdout
 //	zlog.RouteAll(zlog.ConsoleJSONSink(true))
-func ConsoleJSONSink(stdout bool) *Sink {
+//
+//	// logfmt to stdout
+//	zlog.RouteAll(zlog.ConsoleJSONSink(true, zlog.WithEncoder(zlog.LogfmtEncoder{})))
+func ConsoleJSONSink(stdout bool, options ...ConsoleOption) *Sink {
 	output := os.Stderr
 	name := "console-stderr-json"
 	if stdout {
@@ -77,52 +61,146 @@ func ConsoleJSONSink(stdout bool) *Sink {
 		name = "console-stdout-json"
 	}
 
-	return NewSink(name, func(_ context.Context, event Log) error {
-		// Build JSON structure
-		entry := map[string]interface{}{
-			"time":    event.Time.Format(time.RFC3339Nano),
-			"signal":  string(event.Signal),
-			"message": event.Message,
-		}
+	config := &consoleConfig{encoder: JSONEncoder{}}
+	for _, option := range options {
+		option.applyConsole(config)
+	}
+	return newWriterSink(name, output, config.encoder)
+}
 
-		// Add caller info if available
-		if event.Caller.File != "" {
-			entry["caller"] = fmt.Sprintf("%s:%d", event.Caller.File, event.Caller.Line)
-		}
+// standardLevel holds the minimum signal for EnableStandardLogging.
+// It is read on every event, so changes take effect immediately.
+var standardLevel atomic.Value
 
-		// Add all structured fields
-		for _, field := range event.Data {
-			entry[field.Key] = field.Value
-		}
+// standardRoute tracks where the standard sink is hooked so repeated calls to
+// EnableStandardLogging adjust the level instead of adding duplicate routes.
+var standardRoute struct {
+	logger *Logger[Fields]
+	mu     sync.Mutex
+}
 
-		encoder := json.NewEncoder(output)
-		return encoder.Encode(entry)
-	})
+// standardSink is stderrJSONSink gated by the current minimum level.
+var standardSink = stderrJSONSink.WithFilter(func(_ context.Context, event Log) bool {
+	return standardMatcher{}.Match(event.Signal) && standardMatcher{}.admits(event.Signal)
+})
+
+// standardMatcher routes every signal with a severity to standardSink. The
+// level is applied by the sink's filter, so SetLevel never touches routes;
+// admits reports it to Enabled.
+type standardMatcher struct{}
+
+// Match implements SignalMatcher.
+func (standardMatcher) Match(signal Signal) bool {
+	return severityOf(signal) != SeverityNone
+}
+
+// admits reports whether the current level lets the signal through.
+func (standardMatcher) admits(signal Signal) bool {
+	return severityOf(signal) >= severityOf(Level())
+}
+
+func init() {
+	standardLevel.Store(INFO)
 }
 
-// EnableStandardLogging enables JSON output to stderr for standard log signals.
+// EnableStandardLogging enables JSON output to stderr for signals with a severity.
 // The level parameter determines the minimum signal level that will be logged:
 //   - DEBUG: All signals (DEBUG, INFO, WARN, ERROR, FATAL)
 //   - INFO: INFO and above (INFO, WARN, ERROR, FATAL)
 //   - WARN: WARN and above (WARN, ERROR, FATAL)
 //   - ERROR: ERROR and above (ERROR, FATAL)
 //   - FATAL: Only FATAL
+//
+// Custom signals registered with RegisterSignal take part according to their
+// severity, so a FRAUD_DETECTED signal registered at SeverityError is logged
+// whenever the level is ERROR or below. Any registered signal can be used as
+// the level. Signals without a severity are never routed here.
+//
+// Calling EnableStandardLogging again only changes the level, exactly like
+// SetLevel; the stderr route is installed once per default logger.
 func EnableStandardLogging(level Signal) {
-	// Route signals based on level
-	switch level {
-	case DEBUG:
-		RouteSignal(DEBUG, stderrJSONSink)
-		fallthrough
-	case INFO:
-		RouteSignal(INFO, stderrJSONSink)
-		fallthrough
-	case WARN:
-		RouteSignal(WARN, stderrJSONSink)
-		fallthrough
-	case ERROR:
-		RouteSignal(ERROR, stderrJSONSink)
-		fallthrough
-	case FATAL:
-		RouteSignal(FATAL, stderrJSONSink)
+	if severityOf(level) == SeverityNone {
+		return
+	}
+	SetLevel(level)
+
+	standardRoute.mu.Lock()
+	defer standardRoute.mu.Unlock()
+
+	logger := currentLogger()
+	if standardRoute.logger == logger {
+		return
+	}
+	standardRoute.logger = logger
+
+	// Route every signal with a severity; the sink's filter applies the level
+	logger.RegisterMatch(standardMatcher{}, standardSink)
+}
+
+// SetLevel changes the minimum signal logged by EnableStandardLogging.
+//
+// The change is atomic and takes effect for the next event, without
+// restarting or re-hooking anything. Typical drivers are a signal handler,
+// an admin endpoint (see LevelHandler) or a config reload:
+//
+//	sigs := make(chan os.Signal, 1)
+//	signal.Notify(sigs, syscall.SIGUSR1)
+//	go func() {
+//	    for range sigs {
+//	        if zlog.Level() == zlog.DEBUG {
+//	            zlog.SetLevel(zlog.INFO)
+//	        } else {
+//	            zlog.SetLevel(zlog.DEBUG)
+//	        }
+//	    }
+//	}()
+//
+// Signals without a registered severity are ignored.
+func SetLevel(level Signal) {
+	if severityOf(level) == SeverityNone {
+		return
 	}
+	standardLevel.Store(level)
+}
+
+// Level returns the minimum signal logged by EnableStandardLogging.
+// The default is INFO.
+func Level() Signal {
+	return standardLevel.Load().(Signal) //nolint:errcheck // Only Signal values are stored
+}
+
+// LevelHandler returns an HTTP handler for reading and changing the level at
+// runtime. GET responds with the current level; PUT or POST with the new level
+// as the request body (e.g. "WARN") changes it.
+//
+//	http.Handle("/admin/log-level", zlog.LevelHandler())
+//
+//	// curl -X PUT -d WARN localhost:8080/admin/log-level
+//
+// Mount it behind whatever authentication protects your admin endpoints.
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			body, err := io.ReadAll(io.LimitReader(r.Body, 256))
+			if err != nil {
+				http.Error(w, "failed to read level", http.StatusBadRequest)
+				return
+			}
+			level := Signal(strings.ToUpper(strings.TrimSpace(string(body))))
+			if severityOf(level) == SeverityNone {
+				http.Error(w, fmt.Sprintf("unknown level %q", level), http.StatusBadRequest)
+				return
+			}
+			SetLevel(level)
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprintln(w, Level())
+	})
 }
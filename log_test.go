@@ -5,12 +5,17 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 )
 
-//nolint:revive // t is unused but required for test function signature
 func TestEnableStandardLogging(t *testing.T) {
+	original := Level()
+	t.Cleanup(func() { SetLevel(original) })
+
 	// Test that EnableStandardLogging with DEBUG level sets up all signals
 	EnableStandardLogging(DEBUG)
 
@@ -86,3 +91,159 @@ func TestStderrJSONSinkOutput(t *testing.T) {
 		t.Error("missing time field")
 	}
 }
+
+func TestAddJSONFieldsGroups(t *testing.T) {
+	entry := map[string]interface{}{}
+	addJSONFields(entry, []Field{
+		String("service", "api"),
+		Group("http", String("method", "GET"), Group("response", Int("status", 200))),
+	})
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"http":{"method":"GET","response":{"status":200}},"service":"api"}`
+	if string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	original := Level()
+	t.Cleanup(func() { SetLevel(original) })
+
+	if original != INFO {
+		t.Errorf("default level = %s, want INFO", original)
+	}
+
+	SetLevel(WARN)
+	if Level() != WARN {
+		t.Errorf("Level() = %s, want WARN", Level())
+	}
+
+	// Signals without a severity are ignored
+	SetLevel(AUDIT)
+	if Level() != WARN {
+		t.Errorf("Level() = %s after SetLevel(AUDIT), want WARN", Level())
+	}
+}
+
+func TestEnableStandardLoggingHooksOnce(t *testing.T) {
+	original := defaultLogger
+	originalLevel := Level()
+	defaultLogger = NewLogger[Fields]()
+	t.Cleanup(func() {
+		defaultLogger = original
+		SetLevel(originalLevel)
+	})
+
+	EnableStandardLogging(DEBUG)
+	EnableStandardLogging(ERROR)
+
+	defaultLogger.mu.RLock()
+	patterns := len(defaultLogger.patterns)
+	defaultLogger.mu.RUnlock()
+
+	if patterns != 1 {
+		t.Errorf("expected 1 standard route, got %d", patterns)
+	}
+	if Level() != ERROR {
+		t.Errorf("Level() = %s, want ERROR", Level())
+	}
+}
+
+func TestEnabledRespectsLevel(t *testing.T) {
+	original := defaultLogger
+	originalLevel := Level()
+	defaultLogger = NewLogger[Fields]()
+	t.Cleanup(func() {
+		defaultLogger = original
+		SetLevel(originalLevel)
+	})
+
+	EnableStandardLogging(INFO)
+	if Enabled(DEBUG) || !Enabled(INFO) || !Enabled(ERROR) {
+		t.Error("Enabled does not reflect the standard level")
+	}
+
+	SetLevel(WARN)
+	if Enabled(INFO) || !Enabled(WARN) {
+		t.Error("Enabled does not follow SetLevel")
+	}
+
+	// Other routes for the signal still count
+	Hook(DEBUG, NewSink("debug", func(_ context.Context, _ Log) error { return nil }))
+	if !Enabled(DEBUG) {
+		t.Error("exact route below the level not reported")
+	}
+}
+
+func TestStandardSinkRespectsLevel(t *testing.T) {
+	original := Level()
+	t.Cleanup(func() { SetLevel(original) })
+
+	old := os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	os.Stderr = w
+	defer func() {
+		os.Stderr = old
+	}()
+
+	ctx := context.Background()
+	SetLevel(WARN)
+	_, _ = standardSink.Process(ctx, NewEvent(INFO, "hidden", nil)) //nolint:errcheck // Test
+	SetLevel(DEBUG)
+	_, _ = standardSink.Process(ctx, NewEvent(INFO, "shown", nil)) //nolint:errcheck // Test
+
+	w.Close()
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+
+	output := buf.String()
+	if bytes.Contains(buf.Bytes(), []byte("hidden")) {
+		t.Errorf("event below level was written: %s", output)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("shown")) {
+		t.Errorf("event at level was not written: %s", output)
+	}
+}
+
+func TestLevelHandler(t *testing.T) {
+	original := Level()
+	t.Cleanup(func() { SetLevel(original) })
+
+	handler := LevelHandler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader("warn\n")))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PUT status = %d, want 200", rec.Code)
+	}
+	if Level() != WARN {
+		t.Errorf("Level() = %s, want WARN", Level())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if got := strings.TrimSpace(rec.Body.String()); got != "WARN" {
+		t.Errorf("GET body = %q, want WARN", got)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", strings.NewReader("LOUD")))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown level status = %d, want 400", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE status = %d, want 405", rec.Code)
+	}
+}
@@ -0,0 +1,168 @@
+package zlog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// LogfmtEncoder formats events as logfmt, one line of key=value pairs per
+// event:
+//
+//	time=2023-10-20T15:04:05Z signal=INFO message="User logged in" caller=auth.go:42 user_id=123
+//
+// Members of groups and objects get dotted keys (http.status=200), arrays
+// are written as [a b c], and values with spaces, quotes or '=' are quoted.
+type LogfmtEncoder struct{}
+
+// Encode implements Encoder.
+func (LogfmtEncoder) Encode(event Log, buf *Buffer) error {
+	buf.WriteString("time=")
+	buf.WriteString(event.Time.Format(time.RFC3339Nano))
+	buf.WriteString(" signal=")
+	writeLogfmtValue(buf, string(event.Signal))
+	buf.WriteString(" message=")
+	writeLogfmtValue(buf, event.Message)
+	if caller := event.Caller.Resolve(); caller.File != "" {
+		buf.WriteString(" caller=")
+		writeLogfmtValue(buf, fmt.Sprintf("%s:%d", caller.File, caller.Line))
+	}
+	writeLogfmtFields(buf, "", event.Data)
+	buf.WriteByte('\n')
+	return nil
+}
+
+// ContentType returns the MIME type of the encoded entries.
+func (LogfmtEncoder) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+// TextEncoder formats events as plain single lines: the time, signal,
+// message and caller, followed by the fields in logfmt style:
+//
+//	2023-10-20T15:04:05Z INFO User logged in (auth.go:42) user_id=123
+//
+// Unlike PrettyEncoder it uses no colors, symbols or extra lines, so the
+// output suits files and log collectors that expect one event per line.
+// Messages containing newlines or other control characters are quoted, so
+// a message can never start a line of its own.
+type TextEncoder struct{}
+
+// Encode implements Encoder.
+func (TextEncoder) Encode(event Log, buf *Buffer) error {
+	buf.WriteString(event.Time.Format(time.RFC3339Nano))
+	buf.WriteByte(' ')
+	writeTextValue(buf, string(event.Signal))
+	buf.WriteByte(' ')
+	writeTextValue(buf, event.Message)
+	if caller := event.Caller.Resolve(); caller.File != "" {
+		buf.WriteString(" (")
+		writeTextValue(buf, caller.File+":"+strconv.Itoa(caller.Line))
+		buf.WriteByte(')')
+	}
+	writeLogfmtFields(buf, "", event.Data)
+	buf.WriteByte('\n')
+	return nil
+}
+
+// ContentType returns the MIME type of the encoded entries.
+func (TextEncoder) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+// writeLogfmtFields writes " key=value" for each field, flattening groups
+// and objects into dotted keys.
+func writeLogfmtFields(buf *Buffer, prefix string, fields []Field) {
+	for _, field := range fields {
+		key := prefix + field.Key
+		members, nested, err := nestedFields(field)
+		if err == nil && nested && field.Type != ArrayType {
+			writeLogfmtFields(buf, key+".", members)
+			continue
+		}
+
+		buf.WriteByte(' ')
+		buf.WriteString(key)
+		buf.WriteByte('=')
+		writeLogfmtValue(buf, textValue(field))
+	}
+}
+
+// writeLogfmtValue writes s, quoted if logfmt requires it.
+func writeLogfmtValue(buf *Buffer, s string) {
+	if needsQuoting(s) {
+		buf.bytes = strconv.AppendQuote(buf.bytes, s)
+		return
+	}
+	buf.WriteString(s)
+}
+
+// writeTextValue writes s as is, unless it contains control characters or
+// invalid UTF-8, in which case it is quoted and escaped.
+func writeTextValue(buf *Buffer, s string) {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < ' ' || c == 0x7f {
+			buf.bytes = strconv.AppendQuote(buf.bytes, s)
+			return
+		}
+	}
+	if !utf8.ValidString(s) {
+		buf.bytes = strconv.AppendQuote(buf.bytes, s)
+		return
+	}
+	buf.WriteString(s)
+}
+
+// needsQuoting reports whether s must be quoted to stay one logfmt value.
+func needsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c <= ' ' || c == '=' || c == '"' || c == 0x7f {
+			return true
+		}
+	}
+	return !utf8.ValidString(s)
+}
+
+// textValue renders a field value as text: errors as their message, times
+// as RFC 3339, nested fields as {key=value ...} and arrays as [a b c].
+func textValue(field Field) string {
+	members, nested, err := nestedFields(field)
+	switch {
+	case err != nil:
+		return err.Error()
+	case nested:
+		parts := make([]string, len(members))
+		for i, member := range members {
+			parts[i] = textValue(member)
+			if field.Type != ArrayType {
+				parts[i] = member.Key + "=" + parts[i]
+			}
+		}
+		if field.Type == ArrayType {
+			return "[" + strings.Join(parts, " ") + "]"
+		}
+		return "{" + strings.Join(parts, " ") + "}"
+	}
+
+	switch value := field.Value.(type) {
+	case string:
+		return value
+	case error:
+		return value.Error()
+	case time.Time:
+		return value.Format(time.RFC3339Nano)
+	case []time.Time:
+		parts := make([]string, len(value))
+		for i, t := range value {
+			parts[i] = t.Format(time.RFC3339Nano)
+		}
+		return "[" + strings.Join(parts, " ") + "]"
+	default:
+		return fmt.Sprint(value)
+	}
+}
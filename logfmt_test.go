@@ -0,0 +1,106 @@
+package zlog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogfmtEncoder(t *testing.T) {
+	event := testEvent("User logged in",
+		String("user", "ada lovelace"),
+		String("empty", ""),
+		String("query", `a="b"`),
+		Int("attempt", 2),
+		Duration("took", 1500*time.Millisecond),
+		Err(errors.New("bad password")),
+		Group("http", String("method", "GET"), Group("response", Int("status", 401))),
+		Strings("tags", []string{"a", "b"}),
+		Object("user_obj", testUser{id: "u1", roles: testRoles{"admin"}}),
+		Array("roles", testRoles{"admin", "dev"}),
+	)
+
+	buf := &Buffer{}
+	if err := (LogfmtEncoder{}).Encode(event, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `time=2024-03-01T12:00:00Z signal=INFO message="User logged in" caller=auth.go:42` +
+		` user="ada lovelace" empty="" query="a=\"b\"" attempt=2 took=1.5s error="bad password"` +
+		` http.method=GET http.response.status=401 tags="[a b]"` +
+		` user_obj.id=u1 user_obj.age=0 user_obj.roles=[admin] roles="[admin dev]"` + "\n"
+	if buf.String() != want {
+		t.Errorf("got  %s\nwant %s", buf.String(), want)
+	}
+}
+
+func TestTextEncoder(t *testing.T) {
+	event := testEvent("Payment failed", Float64("amount", 9.5), Time("at", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+	event.Signal = ERROR
+
+	buf := &Buffer{}
+	if err := (TextEncoder{}).Encode(event, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "2024-03-01T12:00:00Z ERROR Payment failed (auth.go:42) amount=9.5 at=2024-03-01T00:00:00Z\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestTextEncoderEscapesMessage(t *testing.T) {
+	event := testEvent("login ok\n2024-03-01T12:00:01Z INFO user=admin promoted")
+
+	buf := &Buffer{}
+	if err := (TextEncoder{}).Encode(event, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `2024-03-01T12:00:00Z INFO "login ok\n2024-03-01T12:00:01Z INFO user=admin promoted" (auth.go:42)` + "\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Error("a message must not add lines to the output")
+	}
+}
+
+func TestTextValue(t *testing.T) {
+	when := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		field Field
+		want  string
+	}{
+		{Err(nil), "<nil>"},
+		{Times("t", []time.Time{when, when}), "[2024-03-01T00:00:00Z 2024-03-01T00:00:00Z]"},
+		{Group("g", Int("a", 1), String("b", "x")), "{a=1 b=x}"},
+		{Array("team", testTeam{{id: "u1"}}), "[{id=u1 age=0 roles=[]}]"},
+		{Object("bad", failingObject{}), "cannot marshal"},
+	}
+
+	for _, tt := range tests {
+		if got := textValue(tt.field); got != tt.want {
+			t.Errorf("textValue(%s) = %q, want %q", tt.field.Key, got, tt.want)
+		}
+	}
+}
+
+func TestNeedsQuoting(t *testing.T) {
+	tests := map[string]bool{
+		"plain":     false,
+		"ünïcode":   false,
+		"":          true,
+		"two words": true,
+		"a=b":       true,
+		`say "hi"`:  true,
+		"tab\there": true,
+		"\xff":      true,
+	}
+	for value, want := range tests {
+		if got := needsQuoting(value); got != want {
+			t.Errorf("needsQuoting(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
@@ -2,7 +2,7 @@ package zlog
 
 import (
 	"context"
-	"runtime"
+	"errors"
 	"sync"
 	"time"
 
@@ -51,13 +51,26 @@ type TypedHook[T any] = pipz.Chainable[T]
 //	orderLogger.Hook(ORDER_CREATED, auditHook)
 //	orderLogger.Emit(ORDER_CREATED, "Order created", order)
 type Logger[T any] struct {
-	pipeline  *pipz.Sequence[Event[T]]              // Root sequence for HookAll processors
-	router    *pipz.Switch[Event[T], Signal]        // Signal-based router
-	hooks     map[Signal][]pipz.Chainable[Event[T]] // Track hooks per signal
-	scaffolds map[Signal]*pipz.Scaffold[Event[T]]   // Track scaffold processors for updates
+	pipeline  *pipz.Sequence[Event[T]]            // Root sequence for HookAll processors
+	router    *pipz.Switch[Event[T], Signal]      // Signal-based router
+	hooks     map[Signal][]hookEntry[T]           // Track hooks per signal
+	scaffolds map[Signal]*pipz.Scaffold[Event[T]] // Track scaffold processors for updates
+	globals   []hookEntry[T]                      // Track HookAll processors for rebuilds
+	patterns  []patternEntry[T]                   // Hooks for signals matching a SignalMatcher
+	unmatched []hookEntry[T]                      // Hooks for signals with no other route
+	resolved  map[Signal]struct{}                 // Signals whose routes include pattern hooks
+	work      inflight                            // In-flight events, including scaffold work
+	nextID    uint64
+	revision  uint64 // Bumped whenever pattern or unmatched hooks change
 	mu        sync.RWMutex
 }
 
+// hookEntry pairs a hook with the identifier used by its Registration.
+type hookEntry[T any] struct {
+	hook pipz.Chainable[Event[T]]
+	id   uint64
+}
+
 // NewLogger creates a typed logger that processes Event[T] types.
 //
 // The logger processes events through a pipeline with signal-based routing,
@@ -69,8 +82,9 @@ type Logger[T any] struct {
 //	orderLogger.Emit(ORDER_CREATED, "Order created", order)
 func NewLogger[T any]() *Logger[T] {
 	l := &Logger[T]{
-		hooks:     make(map[Signal][]pipz.Chainable[Event[T]]),
+		hooks:     make(map[Signal][]hookEntry[T]),
 		scaffolds: make(map[Signal]*pipz.Scaffold[Event[T]]),
+		resolved:  make(map[Signal]struct{}),
 	}
 
 	// Create signal router that extracts the signal from Event.Signal
@@ -85,21 +99,6 @@ func NewLogger[T any]() *Logger[T] {
 	return l
 }
 
-// captureCallerInfo captures the caller information at the specified skip level.
-func captureCallerInfo(skip int) CallerInfo {
-	if pc, file, line, ok := runtime.Caller(skip + 1); ok {
-		fn := runtime.FuncForPC(pc)
-		if fn != nil {
-			return CallerInfo{
-				File:     file,
-				Line:     line,
-				Function: fn.Name(),
-			}
-		}
-	}
-	return CallerInfo{}
-}
-
 // Hook registers one or more hooks to process events with the specified signal.
 //
 // Multiple hooks can process the same signal - they run in parallel using
@@ -108,7 +107,8 @@ func captureCallerInfo(skip int) CallerInfo {
 //
 //	orderLogger.Hook("HIGH_VALUE", auditHook, metricsHook, alertHook)
 //
-// Hooks can be added dynamically without stopping event flow.
+// Hooks can be added dynamically without stopping event flow, and removed
+// again with Unhook, UnhookAll or ReplaceHooks.
 func (l *Logger[T]) Hook(signal Signal, hooks ...pipz.Chainable[Event[T]]) *Logger[T] {
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -119,36 +119,214 @@ func (l *Logger[T]) Hook(signal Signal, hooks ...pipz.Chainable[Event[T]]) *Logg
 	return l
 }
 
+// Register works like Hook but returns a Registration that detaches exactly
+// the hooks added by this call.
+//
+//	reg := orderLogger.Register(ORDER_CREATED, auditHook)
+//	defer reg.Remove()
+func (l *Logger[T]) Register(signal Signal, hooks ...pipz.Chainable[Event[T]]) *Registration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ids := make([]uint64, 0, len(hooks))
+	for _, hook := range hooks {
+		ids = append(ids, l.hookSignal(signal, hook))
+	}
+
+	return newRegistration(func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.removeSignalHooks(signal, func(entry hookEntry[T]) bool {
+			return containsID(ids, entry.id)
+		})
+	})
+}
+
 // hookSignal adds a single hook for the specified signal (internal method).
 // This mirrors the same optimization strategy as the global dispatch system.
-func (l *Logger[T]) hookSignal(signal Signal, hook pipz.Chainable[Event[T]]) {
+// It returns the identifier assigned to the hook.
+func (l *Logger[T]) hookSignal(signal Signal, hook pipz.Chainable[Event[T]]) uint64 {
 	// Add hook to our tracking
-	l.hooks[signal] = append(l.hooks[signal], hook)
-	hooks := l.hooks[signal]
+	l.nextID++
+	l.hooks[signal] = append(l.hooks[signal], hookEntry[T]{hook: hook, id: l.nextID})
+	l.setSignalHooks(signal, l.hooks[signal])
+	attachHook(hook)
 
-	switch len(hooks) {
-	case 1:
-		// First hook - add directly to switch
-		l.router.AddRoute(signal, hook)
+	return l.nextID
+}
 
-	case 2:
-		// Second hook - need to switch to scaffold for parallel processing
-		// Get the first hook from our routes
-		firstHook := l.hooks[signal][0]
+// removeSignalHooks drops every hook for the signal that matches the predicate
+// and rebuilds the route. It reports whether anything was removed.
+func (l *Logger[T]) removeSignalHooks(signal Signal, match func(hookEntry[T]) bool) bool {
+	entries := l.hooks[signal]
+	kept := make([]hookEntry[T], 0, len(entries))
+	for _, entry := range entries {
+		if match(entry) {
+			detachHook(entry.hook)
+		} else {
+			kept = append(kept, entry)
+		}
+	}
 
-		// Create scaffold with both hooks for fire-and-forget parallel execution
-		scaffold := pipz.NewScaffold[Event[T]](string(signal), firstHook, hook)
+	if len(kept) == len(entries) {
+		return false
+	}
 
-		// Replace route and store scaffold for future updates
-		l.router.AddRoute(signal, scaffold)
-		l.scaffolds[signal] = scaffold
+	l.setSignalHooks(signal, kept)
+	return true
+}
+
+// setSignalHooks installs the given hooks as the complete set of exact hooks
+// for a signal and rebuilds its route.
+func (l *Logger[T]) setSignalHooks(signal Signal, entries []hookEntry[T]) {
+	if len(entries) == 0 {
+		delete(l.hooks, signal)
+	} else {
+		l.hooks[signal] = entries
+	}
+	l.installRoute(signal)
+}
+
+// installRoute rebuilds the router entry for a signal from its exact hooks,
+// any matching pattern hooks and, failing both, the unmatched hooks. Routes
+// collapse back to a single hook or are removed entirely as needed.
+func (l *Logger[T]) installRoute(signal Signal) {
+	l.installEntries(signal, l.routeEntries(signal))
+}
+
+// installEntries sets the router entry for a signal to the given hooks.
+func (l *Logger[T]) installEntries(signal Signal, entries []hookEntry[T]) {
+	switch len(entries) {
+	case 0:
+		// Last hook gone - drop the route so the signal is no longer handled
+		l.router.RemoveRoute(signal)
+		delete(l.scaffolds, signal)
+
+	case 1:
+		// Single hook - route directly, no scaffold needed
+		l.router.AddRoute(signal, entries[0].hook)
+		delete(l.scaffolds, signal)
 
 	default:
-		// 3+ hooks - just add to existing scaffold
-		if scaffold, ok := l.scaffolds[signal]; ok {
-			scaffold.Add(hook)
+		// Multiple hooks - build a fresh scaffold for fire-and-forget parallel
+		// execution rather than mutating the live one. Each hook is tracked so
+		// Shutdown can wait for the background work to finish.
+		hooks := make([]pipz.Chainable[Event[T]], len(entries))
+		for i, entry := range entries {
+			hooks[i] = trackedHook[T]{hook: entry.hook, work: &l.work}
+		}
+		scaffold := pipz.NewScaffold[Event[T]](string(signal), hooks...)
+
+		l.router.AddRoute(signal, trackedScaffold[T]{scaffold: scaffold, work: &l.work, size: len(hooks)})
+		l.scaffolds[signal] = scaffold
+	}
+}
+
+// trackedScaffold registers one unit of in-flight work per scaffold hook
+// before handing the event to the scaffold.
+type trackedScaffold[T any] struct {
+	scaffold *pipz.Scaffold[Event[T]]
+	work     *inflight
+	size     int
+}
+
+// Process implements pipz.Chainable.
+func (t trackedScaffold[T]) Process(ctx context.Context, event Event[T]) (Event[T], error) {
+	t.work.add(t.size)
+	return t.scaffold.Process(ctx, event)
+}
+
+// Name implements pipz.Chainable.
+func (t trackedScaffold[T]) Name() pipz.Name {
+	return t.scaffold.Name()
+}
+
+// trackedHook marks its unit of in-flight work as done once the hook returns.
+type trackedHook[T any] struct {
+	hook pipz.Chainable[Event[T]]
+	work *inflight
+}
+
+// Process implements pipz.Chainable.
+func (t trackedHook[T]) Process(ctx context.Context, event Event[T]) (Event[T], error) {
+	defer t.work.done()
+	return t.hook.Process(ctx, event)
+}
+
+// Name implements pipz.Chainable.
+func (t trackedHook[T]) Name() pipz.Name {
+	return t.hook.Name()
+}
+
+// Unhook removes a hook from the specified signal.
+//
+// Hooks are matched by identity, so pass the same value that was given to Hook.
+// Pointer-based hooks such as *Sink or pipz connectors match reliably; plain
+// processors built with pipz.Effect or pipz.Apply cannot be compared and should
+// be removed through the Registration returned by Register instead.
+//
+// When the last hook for a signal is removed, the route is dropped entirely.
+// Unhook reports whether anything was removed.
+//
+//	orderLogger.Unhook(ORDER_CREATED, auditHook)
+func (l *Logger[T]) Unhook(signal Signal, hook pipz.Chainable[Event[T]]) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.removeSignalHooks(signal, func(entry hookEntry[T]) bool {
+		return sameHook(entry.hook, hook)
+	})
+}
+
+// UnhookAll removes a hook from every signal it is attached to, as well as
+// from pattern, unmatched and HookAll registrations. It reports whether anything was removed.
+//
+//	orderLogger.UnhookAll(auditHook)
+func (l *Logger[T]) UnhookAll(hook pipz.Chainable[Event[T]]) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	match := func(entry hookEntry[T]) bool {
+		return sameHook(entry.hook, hook)
+	}
+
+	removed := false
+	for signal := range l.hooks {
+		if l.removeSignalHooks(signal, match) {
+			removed = true
 		}
 	}
+	if l.removePatternHooks(match) {
+		removed = true
+	}
+	if l.removeGlobalHooks(match) {
+		removed = true
+	}
+	return removed
+}
+
+// ReplaceHooks atomically swaps all hooks for a signal with the given set.
+// Passing no hooks removes the signal's own hooks; hooks registered through
+// HookMatch or HookUnmatched still apply.
+//
+//	// Feature flag flipped - send orders somewhere else
+//	orderLogger.ReplaceHooks(ORDER_CREATED, newAuditHook)
+func (l *Logger[T]) ReplaceHooks(signal Signal, hooks ...pipz.Chainable[Event[T]]) *Logger[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, entry := range l.hooks[signal] {
+		detachHook(entry.hook)
+	}
+	entries := make([]hookEntry[T], 0, len(hooks))
+	for _, hook := range hooks {
+		l.nextID++
+		entries = append(entries, hookEntry[T]{hook: hook, id: l.nextID})
+		attachHook(hook)
+	}
+	l.setSignalHooks(signal, entries)
+	return l
 }
 
 // HookAll registers one or more hooks to process ALL events before signal routing.
@@ -165,11 +343,69 @@ func (l *Logger[T]) HookAll(hooks ...pipz.Chainable[Event[T]]) *Logger[T] {
 	defer l.mu.Unlock()
 
 	for _, hook := range hooks {
-		l.pipeline.Register(hook)
+		l.hookGlobal(hook)
 	}
 	return l
 }
 
+// RegisterAll works like HookAll but returns a Registration that detaches
+// exactly the hooks added by this call.
+func (l *Logger[T]) RegisterAll(hooks ...pipz.Chainable[Event[T]]) *Registration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ids := make([]uint64, 0, len(hooks))
+	for _, hook := range hooks {
+		ids = append(ids, l.hookGlobal(hook))
+	}
+
+	return newRegistration(func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.removeGlobalHooks(func(entry hookEntry[T]) bool {
+			return containsID(ids, entry.id)
+		})
+	})
+}
+
+// hookGlobal appends a hook to the root pipeline and returns its identifier.
+func (l *Logger[T]) hookGlobal(hook pipz.Chainable[Event[T]]) uint64 {
+	l.nextID++
+	l.globals = append(l.globals, hookEntry[T]{hook: hook, id: l.nextID})
+	l.pipeline.Register(hook)
+	attachHook(hook)
+	return l.nextID
+}
+
+// removeGlobalHooks drops every HookAll processor matching the predicate.
+// The root pipeline is rebuilt and swapped in, so in-flight events finish
+// against the pipeline they started with.
+func (l *Logger[T]) removeGlobalHooks(match func(hookEntry[T]) bool) bool {
+	kept := make([]hookEntry[T], 0, len(l.globals))
+	for _, entry := range l.globals {
+		if match(entry) {
+			detachHook(entry.hook)
+		} else {
+			kept = append(kept, entry)
+		}
+	}
+
+	if len(kept) == len(l.globals) {
+		return false
+	}
+
+	pipeline := pipz.NewSequence[Event[T]]("typed-pipeline")
+	pipeline.Register(l.router)
+	for _, entry := range kept {
+		pipeline.Register(entry.hook)
+	}
+
+	l.globals = kept
+	l.pipeline = pipeline
+	return true
+}
+
 // WithFilter adds a filter to the logger pipeline that only allows events
 // matching the predicate to continue processing.
 //
@@ -248,11 +484,156 @@ func (l *Logger[T]) Emit(signal Signal, message string, data T) {
 	l.Process(event)
 }
 
+// EmitSkip works like Emit but reports the caller skip frames further up the
+// stack, for helpers that wrap Emit.
+func (l *Logger[T]) EmitSkip(skip int, signal Signal, message string, data T) {
+	event := Event[T]{
+		Time:    time.Now(),
+		Signal:  signal,
+		Message: message,
+		Data:    data,
+		Caller:  captureCallerInfo(1 + skip),
+	}
+	l.Process(event)
+}
+
+// EmitContext works like Emit but passes ctx to every hook and sink, so
+// request-scoped values such as trace IDs reach them directly.
+//
+//	orderLogger.EmitContext(ctx, ORDER_CREATED, "Order created", order)
+func (l *Logger[T]) EmitContext(ctx context.Context, signal Signal, message string, data T) {
+	event := Event[T]{
+		Time:    time.Now(),
+		Signal:  signal,
+		Message: message,
+		Data:    data,
+		Caller:  captureCallerInfo(1),
+	}
+	l.ProcessContext(ctx, event)
+}
+
 // Process handles pre-built Event[T] types through the logger pipeline.
 // This method does not capture caller info - it should already be in the event.
+// Events processed after Shutdown has been called are dropped.
+//
+// Hooks receive the context stored with the legacy SetContext, if any;
+// prefer ProcessContext.
 func (l *Logger[T]) Process(event Event[T]) {
-	ctx := getContext()
-	_, _ = l.pipeline.Process(ctx, event) //nolint:errcheck // Errors intentionally ignored in fire-and-forget logging
+	l.ProcessContext(getContext(), event)
+}
+
+// ProcessContext works like Process but passes ctx to every hook and sink.
+//
+// Only the context's values are passed on: cancellation is stripped so an
+// event emitted as a request ends still reaches every sink. For Fields events,
+// registered context extractors add their fields before routing.
+func (l *Logger[T]) ProcessContext(ctx context.Context, event Event[T]) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if !l.work.begin() {
+		if log, ok := any(event).(Log); ok {
+			reportSinkError(ctx, loggerName, log, ErrDropped)
+		}
+		return
+	}
+	defer l.work.done()
+
+	// Install pattern routes the first time a signal is seen. This happens
+	// here rather than in the router so routes never change while the
+	// router is evaluating an event.
+	l.resolve(event.Signal)
+
+	l.mu.RLock()
+	pipeline := l.pipeline
+	l.mu.RUnlock()
+
+	// Structured events pick up fields from registered context extractors
+	if fields, ok := any(event.Data).(Fields); ok {
+		if data, ok := any(withContextFields(ctx, fields)).(T); ok {
+			event.Data = data
+		}
+	}
+
+	_, _ = pipeline.Process(context.WithoutCancel(ctx), event) //nolint:errcheck // Errors intentionally ignored in fire-and-forget logging
+}
+
+// Enabled reports whether events with the signal would reach any hook:
+// a HookAll hook, an exact route, a matching pattern or an unmatched hook.
+//
+// Use it to skip building events nobody receives:
+//
+//	if orderLogger.Enabled(ORDER_AUDITED) {
+//	    orderLogger.Emit(ORDER_AUDITED, "Order audited", buildAuditRecord(order))
+//	}
+//
+// Enabled only looks at routes, not at filters inside the hooks, so a route
+// that filters an event out still counts. The one exception is the route
+// installed by EnableStandardLogging, which counts only for signals at or
+// above the current level.
+func (l *Logger[T]) Enabled(signal Signal) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if len(l.globals) > 0 || len(l.hooks[signal]) > 0 {
+		return true
+	}
+	matched := false
+	for _, pattern := range l.patterns {
+		if !pattern.matcher.Match(signal) {
+			continue
+		}
+		matched = true
+		if gated, ok := pattern.matcher.(gatedMatcher); !ok || gated.admits(signal) {
+			return true
+		}
+	}
+
+	// Unmatched hooks only receive signals no pattern matched
+	return !matched && len(l.unmatched) > 0
+}
+
+// Shutdown gracefully stops the logger.
+//
+// Shutdown proceeds in three steps:
+//  1. Stop accepting events - later calls to Emit and Process are dropped
+//  2. Wait for in-flight events, including parallel hooks running in the background
+//  3. Flush and then close every hook that implements Flusher or Closer,
+//     which includes all sinks and anything they wrap (async work, files)
+//
+// The context bounds the whole operation. If it ends before in-flight work
+// completes, sinks are still flushed and closed on a best-effort basis and the
+// context error is returned alongside any sink errors.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	if err := orderLogger.Shutdown(ctx); err != nil {
+//	    fmt.Fprintln(os.Stderr, "order logger shutdown:", err)
+//	}
+func (l *Logger[T]) Shutdown(ctx context.Context) error {
+	l.work.close()
+	waitErr := l.work.wait(ctx)
+
+	l.mu.RLock()
+	hooks := make([]pipz.Chainable[Event[T]], 0, len(l.globals))
+	for _, entries := range l.hooks {
+		for _, entry := range entries {
+			hooks = append(hooks, entry.hook)
+		}
+	}
+	for _, entry := range l.patterns {
+		hooks = append(hooks, entry.hook)
+	}
+	for _, entry := range l.unmatched {
+		hooks = append(hooks, entry.hook)
+	}
+	for _, entry := range l.globals {
+		hooks = append(hooks, entry.hook)
+	}
+	l.mu.RUnlock()
+
+	return errors.Join(waitErr, shutdownHooks(ctx, hooks))
 }
 
 // Watch configures this logger to forward all events to the global logger
@@ -268,7 +649,7 @@ func (l *Logger[T]) Process(event Event[T]) {
 //	// Event flows through typed hooks, then to global logger
 func (l *Logger[T]) Watch() *Logger[T] {
 	// Add a terminal hook that forwards to the global logger
-	forwarder := pipz.Effect[Event[T]]("global-forward", func(_ context.Context, event Event[T]) error {
+	forwarder := pipz.Effect[Event[T]]("global-forward", func(ctx context.Context, event Event[T]) error {
 		// Convert Event[T] to Log for the global logger
 		globalEvent := Log{
 			Time:    event.Time,
@@ -277,8 +658,8 @@ func (l *Logger[T]) Watch() *Logger[T] {
 			Message: event.Message,
 			Data:    Fields{Data("event", event.Data)},
 		}
-		// Forward to global logger
-		defaultLogger.Process(globalEvent)
+		// Forward to global logger, keeping the event's context
+		currentLogger().ProcessContext(ctx, globalEvent)
 		return nil
 	})
 
@@ -287,7 +668,7 @@ func (l *Logger[T]) Watch() *Logger[T] {
 	defer l.mu.Unlock()
 
 	// Add to HookAll so it runs after all processing
-	l.pipeline.Register(forwarder)
+	l.hookGlobal(forwarder)
 
 	return l
 }
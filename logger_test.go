@@ -341,3 +341,211 @@ func TestLoggerTypedHookProcessing(t *testing.T) {
 		}
 	}
 }
+
+func TestLoggerRegisterRemove(t *testing.T) {
+	logger := NewLogger[TestOrder]()
+
+	var count1, count2 int64
+	hook1 := pipz.Effect[Event[TestOrder]]("hook1", func(_ context.Context, _ Event[TestOrder]) error {
+		atomic.AddInt64(&count1, 1)
+		return nil
+	})
+	hook2 := pipz.Effect[Event[TestOrder]]("hook2", func(_ context.Context, _ Event[TestOrder]) error {
+		atomic.AddInt64(&count2, 1)
+		return nil
+	})
+
+	reg1 := logger.Register("REMOVE_TEST", hook1)
+	reg2 := logger.Register("REMOVE_TEST", hook2)
+
+	logger.mu.RLock()
+	if logger.scaffolds["REMOVE_TEST"] == nil {
+		t.Error("Expected scaffold for two hooks")
+	}
+	logger.mu.RUnlock()
+
+	// Removing one hook collapses the scaffold back to a direct route
+	reg1.Remove()
+	reg1.Remove() // Second call is a no-op
+
+	logger.mu.RLock()
+	hooks := logger.hooks["REMOVE_TEST"]
+	scaffold := logger.scaffolds["REMOVE_TEST"]
+	logger.mu.RUnlock()
+
+	if len(hooks) != 1 {
+		t.Errorf("Expected 1 hook after removal, got %d", len(hooks))
+	}
+	if scaffold != nil {
+		t.Error("Expected scaffold to be removed when one hook remains")
+	}
+
+	logger.Emit("REMOVE_TEST", "after first removal", TestOrder{ID: "1"})
+	if atomic.LoadInt64(&count1) != 0 {
+		t.Error("Removed hook should not receive events")
+	}
+	if atomic.LoadInt64(&count2) != 1 {
+		t.Errorf("Expected remaining hook to receive 1 event, got %d", atomic.LoadInt64(&count2))
+	}
+
+	// Removing the last hook drops the route entirely
+	reg2.Remove()
+
+	logger.mu.RLock()
+	_, exists := logger.hooks["REMOVE_TEST"]
+	logger.mu.RUnlock()
+
+	if exists {
+		t.Error("Expected signal tracking to be removed with the last hook")
+	}
+
+	logger.Emit("REMOVE_TEST", "after second removal", TestOrder{ID: "2"})
+	if atomic.LoadInt64(&count2) != 1 {
+		t.Error("Removed hook should not receive events")
+	}
+}
+
+func TestLoggerUnhook(t *testing.T) {
+	logger := NewLogger[TestOrder]()
+
+	var count int64
+	inner := pipz.Effect[Event[TestOrder]]("inner", func(_ context.Context, _ Event[TestOrder]) error {
+		atomic.AddInt64(&count, 1)
+		return nil
+	})
+	hook := pipz.NewRetry[Event[TestOrder]]("retry", inner, 1)
+
+	logger.Hook("UNHOOK_TEST", hook).Hook("OTHER_TEST", hook)
+
+	if !logger.Unhook("UNHOOK_TEST", hook) {
+		t.Fatal("Expected Unhook to report removal")
+	}
+	if logger.Unhook("UNHOOK_TEST", hook) {
+		t.Error("Expected second Unhook to report nothing removed")
+	}
+
+	logger.Emit("UNHOOK_TEST", "unhooked", TestOrder{})
+	logger.Emit("OTHER_TEST", "still hooked", TestOrder{})
+	if got := atomic.LoadInt64(&count); got != 1 {
+		t.Errorf("Expected 1 event, got %d", got)
+	}
+
+	// Uncomparable hooks never match
+	if logger.Unhook("OTHER_TEST", inner) {
+		t.Error("Expected uncomparable hook not to match")
+	}
+
+	logger.HookAll(hook)
+	if !logger.UnhookAll(hook) {
+		t.Fatal("Expected UnhookAll to report removal")
+	}
+
+	logger.mu.RLock()
+	remainingSignals := len(logger.hooks)
+	remainingGlobals := len(logger.globals)
+	logger.mu.RUnlock()
+
+	if remainingSignals != 0 || remainingGlobals != 0 {
+		t.Errorf("Expected no hooks left, got %d signals and %d globals", remainingSignals, remainingGlobals)
+	}
+}
+
+func TestLoggerReplaceHooks(t *testing.T) {
+	logger := NewLogger[TestOrder]()
+
+	var oldCount, newCount int64
+	oldHook := pipz.Effect[Event[TestOrder]]("old", func(_ context.Context, _ Event[TestOrder]) error {
+		atomic.AddInt64(&oldCount, 1)
+		return nil
+	})
+	newHook := pipz.Effect[Event[TestOrder]]("new", func(_ context.Context, _ Event[TestOrder]) error {
+		atomic.AddInt64(&newCount, 1)
+		return nil
+	})
+
+	logger.Hook("REPLACE_TEST", oldHook, oldHook)
+	logger.ReplaceHooks("REPLACE_TEST", newHook)
+
+	logger.Emit("REPLACE_TEST", "replaced", TestOrder{})
+	if atomic.LoadInt64(&oldCount) != 0 {
+		t.Error("Replaced hooks should not receive events")
+	}
+	if atomic.LoadInt64(&newCount) != 1 {
+		t.Errorf("Expected new hook to receive 1 event, got %d", atomic.LoadInt64(&newCount))
+	}
+
+	logger.ReplaceHooks("REPLACE_TEST")
+	logger.mu.RLock()
+	_, exists := logger.hooks["REPLACE_TEST"]
+	logger.mu.RUnlock()
+	if exists {
+		t.Error("Expected route to be removed when replacing with no hooks")
+	}
+}
+
+func TestLoggerRegisterAllRemove(t *testing.T) {
+	logger := NewLogger[TestOrder]()
+
+	var count int64
+	hook := pipz.Effect[Event[TestOrder]]("global", func(_ context.Context, _ Event[TestOrder]) error {
+		atomic.AddInt64(&count, 1)
+		return nil
+	})
+
+	reg := logger.RegisterAll(hook)
+	logger.Emit("ANY", "before removal", TestOrder{})
+	reg.Remove()
+	logger.Emit("ANY", "after removal", TestOrder{})
+
+	if got := atomic.LoadInt64(&count); got != 1 {
+		t.Errorf("Expected 1 event, got %d", got)
+	}
+}
+
+func TestLoggerEmitContext(t *testing.T) {
+	testKey := contextKey("order")
+	ctx := context.WithValue(context.Background(), testKey, "order-1")
+
+	var received any
+	logger := NewLogger[TestOrder]()
+	logger.HookAll(pipz.Effect[Event[TestOrder]]("capture", func(ctx context.Context, _ Event[TestOrder]) error {
+		received = ctx.Value(testKey)
+		return nil
+	}))
+
+	logger.EmitContext(ctx, "ORDER_CREATED", "created", TestOrder{ID: "order-1"})
+
+	if received != "order-1" {
+		t.Errorf("hook context value = %v, want order-1", received)
+	}
+}
+
+func TestLoggerEnabled(t *testing.T) {
+	noop := pipz.Effect[Event[string]]("noop", func(_ context.Context, _ Event[string]) error { return nil })
+
+	logger := NewLogger[string]()
+	if logger.Enabled(INFO) {
+		t.Error("empty logger should not be enabled")
+	}
+
+	logger.Hook(INFO, noop)
+	if !logger.Enabled(INFO) || logger.Enabled(DEBUG) {
+		t.Error("exact route not reflected by Enabled")
+	}
+
+	logger.HookPattern("PAYMENT_*", noop)
+	if !logger.Enabled("PAYMENT_FAILED") || logger.Enabled("ORDER_PLACED") {
+		t.Error("pattern route not reflected by Enabled")
+	}
+
+	reg := logger.RegisterUnmatched(noop)
+	if !logger.Enabled("ORDER_PLACED") {
+		t.Error("unmatched hook should enable every signal")
+	}
+	reg.Remove()
+
+	logger.HookAll(noop)
+	if !logger.Enabled("ORDER_PLACED") {
+		t.Error("HookAll should enable every signal")
+	}
+}
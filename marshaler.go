@@ -0,0 +1,216 @@
+package zlog
+
+import (
+	"time"
+)
+
+// ObjectMarshaler is implemented by types that log themselves as a set of
+// properties. Use it with Object to control exactly what a domain type
+// exposes, without reflection:
+//
+//	func (u User) MarshalLogObject(enc zlog.ObjectEncoder) error {
+//	    enc.AddString("id", u.ID)
+//	    enc.AddString("plan", u.Plan)
+//	    return enc.AddArray("roles", u.Roles) // Roles implements ArrayMarshaler
+//	}
+//
+// Properties not added, such as a password hash, never reach a sink.
+type ObjectMarshaler interface {
+	MarshalLogObject(enc ObjectEncoder) error
+}
+
+// ArrayMarshaler is implemented by types that log themselves as a list.
+// Use it with Array:
+//
+//	type Roles []string
+//
+//	func (r Roles) MarshalLogArray(enc zlog.ArrayEncoder) error {
+//	    for _, role := range r {
+//	        enc.AppendString(role)
+//	    }
+//	    return nil
+//	}
+type ArrayMarshaler interface {
+	MarshalLogArray(enc ArrayEncoder) error
+}
+
+// ObjectEncoder receives the properties of an ObjectMarshaler.
+type ObjectEncoder interface {
+	AddString(key, value string)
+	AddInt(key string, value int)
+	AddInt64(key string, value int64)
+	AddUint64(key string, value uint64)
+	AddFloat64(key string, value float64)
+	AddBool(key string, value bool)
+	AddDuration(key string, value time.Duration)
+	AddTime(key string, value time.Time)
+	AddObject(key string, value ObjectMarshaler) error
+	AddArray(key string, value ArrayMarshaler) error
+}
+
+// ArrayEncoder receives the elements of an ArrayMarshaler.
+type ArrayEncoder interface {
+	AppendString(value string)
+	AppendInt(value int)
+	AppendInt64(value int64)
+	AppendUint64(value uint64)
+	AppendFloat64(value float64)
+	AppendBool(value bool)
+	AppendDuration(value time.Duration)
+	AppendTime(value time.Time)
+	AppendObject(value ObjectMarshaler) error
+	AppendArray(value ArrayMarshaler) error
+}
+
+// Object creates a field for a value that marshals itself. JSON sinks render
+// it as an object, the console sink as a subtree and the slog sink as a
+// group:
+//
+//	zlog.Info("User signed up", zlog.Object("user", user))
+//
+// MarshalLogObject runs each time a sink renders the field. If it returns
+// an error, sinks log the error in place of the value.
+func Object(key string, value ObjectMarshaler) Field {
+	return Field{Key: key, Type: ObjectType, Value: value}
+}
+
+// Array creates a field for a list that marshals itself. JSON sinks render
+// it as an array:
+//
+//	zlog.Info("Permissions changed", zlog.Array("roles", user.Roles))
+func Array(key string, value ArrayMarshaler) Field {
+	return Field{Key: key, Type: ArrayType, Value: value}
+}
+
+// fieldEncoder collects what a marshaler encodes as fields, so sinks can
+// render objects and arrays the way they render groups. Array elements get
+// empty keys. Nested objects and arrays are kept as fields and marshaled
+// when they are rendered.
+type fieldEncoder struct {
+	fields Fields
+}
+
+// AddString implements ObjectEncoder.
+func (e *fieldEncoder) AddString(key, value string) {
+	e.fields = append(e.fields, String(key, value))
+}
+
+// AddInt implements ObjectEncoder.
+func (e *fieldEncoder) AddInt(key string, value int) {
+	e.fields = append(e.fields, Int(key, value))
+}
+
+// AddInt64 implements ObjectEncoder.
+func (e *fieldEncoder) AddInt64(key string, value int64) {
+	e.fields = append(e.fields, Int64(key, value))
+}
+
+// AddUint64 implements ObjectEncoder.
+func (e *fieldEncoder) AddUint64(key string, value uint64) {
+	e.fields = append(e.fields, Uint64(key, value))
+}
+
+// AddFloat64 implements ObjectEncoder.
+func (e *fieldEncoder) AddFloat64(key string, value float64) {
+	e.fields = append(e.fields, Float64(key, value))
+}
+
+// AddBool implements ObjectEncoder.
+func (e *fieldEncoder) AddBool(key string, value bool) {
+	e.fields = append(e.fields, Bool(key, value))
+}
+
+// AddDuration implements ObjectEncoder.
+func (e *fieldEncoder) AddDuration(key string, value time.Duration) {
+	e.fields = append(e.fields, Duration(key, value))
+}
+
+// AddTime implements ObjectEncoder.
+func (e *fieldEncoder) AddTime(key string, value time.Time) {
+	e.fields = append(e.fields, Time(key, value))
+}
+
+// AddObject implements ObjectEncoder.
+func (e *fieldEncoder) AddObject(key string, value ObjectMarshaler) error {
+	e.fields = append(e.fields, Object(key, value))
+	return nil
+}
+
+// AddArray implements ObjectEncoder.
+func (e *fieldEncoder) AddArray(key string, value ArrayMarshaler) error {
+	e.fields = append(e.fields, Array(key, value))
+	return nil
+}
+
+// AppendString implements ArrayEncoder.
+func (e *fieldEncoder) AppendString(value string) {
+	e.AddString("", value)
+}
+
+// AppendInt implements ArrayEncoder.
+func (e *fieldEncoder) AppendInt(value int) {
+	e.AddInt("", value)
+}
+
+// AppendInt64 implements ArrayEncoder.
+func (e *fieldEncoder) AppendInt64(value int64) {
+	e.AddInt64("", value)
+}
+
+// AppendUint64 implements ArrayEncoder.
+func (e *fieldEncoder) AppendUint64(value uint64) {
+	e.AddUint64("", value)
+}
+
+// AppendFloat64 implements ArrayEncoder.
+func (e *fieldEncoder) AppendFloat64(value float64) {
+	e.AddFloat64("", value)
+}
+
+// AppendBool implements ArrayEncoder.
+func (e *fieldEncoder) AppendBool(value bool) {
+	e.AddBool("", value)
+}
+
+// AppendDuration implements ArrayEncoder.
+func (e *fieldEncoder) AppendDuration(value time.Duration) {
+	e.AddDuration("", value)
+}
+
+// AppendTime implements ArrayEncoder.
+func (e *fieldEncoder) AppendTime(value time.Time) {
+	e.AddTime("", value)
+}
+
+// AppendObject implements ArrayEncoder.
+func (e *fieldEncoder) AppendObject(value ObjectMarshaler) error {
+	return e.AddObject("", value)
+}
+
+// AppendArray implements ArrayEncoder.
+func (e *fieldEncoder) AppendArray(value ArrayMarshaler) error {
+	return e.AddArray("", value)
+}
+
+// nestedFields returns the members of a group, object or array field; ok is
+// false for any other field. Objects and arrays are marshaled here, and err
+// is the marshaler's error, which sinks log in place of the value.
+func nestedFields(field Field) (members Fields, ok bool, err error) {
+	switch field.Type {
+	case GroupType:
+		members, ok = field.Value.(Fields)
+	case ObjectType:
+		if value, isMarshaler := field.Value.(ObjectMarshaler); isMarshaler {
+			enc := &fieldEncoder{}
+			err = value.MarshalLogObject(enc)
+			members, ok = enc.fields, true
+		}
+	case ArrayType:
+		if value, isMarshaler := field.Value.(ArrayMarshaler); isMarshaler {
+			enc := &fieldEncoder{}
+			err = value.MarshalLogArray(enc)
+			members, ok = enc.fields, true
+		}
+	}
+	return members, ok, err
+}
@@ -0,0 +1,157 @@
+package zlog
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testUser struct {
+	id       string
+	password string
+	roles    testRoles
+	age      int
+}
+
+func (u testUser) MarshalLogObject(enc ObjectEncoder) error {
+	enc.AddString("id", u.id)
+	enc.AddInt("age", u.age)
+	return enc.AddArray("roles", u.roles)
+}
+
+type testRoles []string
+
+func (r testRoles) MarshalLogArray(enc ArrayEncoder) error {
+	for _, role := range r {
+		enc.AppendString(role)
+	}
+	return nil
+}
+
+type testTeam []testUser
+
+func (t testTeam) MarshalLogArray(enc ArrayEncoder) error {
+	for _, user := range t {
+		if err := enc.AppendObject(user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type failingObject struct{}
+
+func (failingObject) MarshalLogObject(enc ObjectEncoder) error {
+	enc.AddString("partial", "yes")
+	return errors.New("cannot marshal")
+}
+
+// allTypes adds one value of every encoder type.
+type allTypes struct{}
+
+func (allTypes) MarshalLogObject(enc ObjectEncoder) error {
+	enc.AddString("s", "x")
+	enc.AddInt("i", 1)
+	enc.AddInt64("i64", 2)
+	enc.AddUint64("u64", 3)
+	enc.AddFloat64("f", 0.5)
+	enc.AddBool("b", true)
+	enc.AddDuration("d", time.Second)
+	enc.AddTime("t", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	return nil
+}
+
+func (allTypes) MarshalLogArray(enc ArrayEncoder) error {
+	enc.AppendString("x")
+	enc.AppendInt(1)
+	enc.AppendInt64(2)
+	enc.AppendUint64(3)
+	enc.AppendFloat64(0.5)
+	enc.AppendBool(true)
+	enc.AppendDuration(time.Second)
+	enc.AppendTime(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	return enc.AppendArray(testRoles{"nested"})
+}
+
+func renderJSON(t *testing.T, fields ...Field) string {
+	t.Helper()
+	entry := map[string]interface{}{}
+	addJSONFields(entry, fields)
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestObjectAndArrayJSON(t *testing.T) {
+	user := testUser{id: "u1", password: "hunter2", roles: testRoles{"admin", "dev"}, age: 36}
+
+	got := renderJSON(t, Object("user", user), Array("team", testTeam{user}))
+	want := `{"team":[{"age":36,"id":"u1","roles":["admin","dev"]}],"user":{"age":36,"id":"u1","roles":["admin","dev"]}}`
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+	if strings.Contains(got, "hunter2") {
+		t.Error("properties the marshaler leaves out must not be logged")
+	}
+}
+
+func TestObjectEncoderTypes(t *testing.T) {
+	got := renderJSON(t, Object("object", allTypes{}), Array("array", allTypes{}))
+	want := `{"array":["x",1,2,3,0.5,true,1000000000,"2024-03-01T00:00:00Z",["nested"]],` +
+		`"object":{"b":true,"d":1000000000,"f":0.5,"i":1,"i64":2,"s":"x","t":"2024-03-01T00:00:00Z","u64":3}}`
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestObjectMarshalError(t *testing.T) {
+	got := renderJSON(t, Object("thing", failingObject{}))
+	want := `{"thing":{"message":"cannot marshal","type":"*errors.errorString"}}`
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	if lines := formatFieldTree([]Field{Object("thing", failingObject{})}, "", false); len(lines) != 1 || lines[0] != "└─ thing=cannot marshal" {
+		t.Errorf("console rendered %q", lines)
+	}
+}
+
+func TestObjectConsole(t *testing.T) {
+	user := testUser{id: "u1", roles: testRoles{"admin"}, age: 36}
+	want := strings.Join([]string{
+		"",
+		"   └─ user",
+		"      ├─ id=u1",
+		"      ├─ age=36",
+		"      └─ roles",
+		"         └─ 0=admin",
+	}, "\n")
+	if got := formatFields([]Field{Object("user", user)}, false); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestObjectSlog(t *testing.T) {
+	attr := fieldSlogAttr(Object("user", testUser{id: "u1", roles: testRoles{"admin"}}))
+	if attr.Value.Kind() != slog.KindGroup {
+		t.Fatalf("kind = %s, want group", attr.Value.Kind())
+	}
+	members := attr.Value.Group()
+	if len(members) != 3 || members[0].Key != "id" || members[2].Value.Kind() != slog.KindAny {
+		t.Errorf("unexpected members: %v", members)
+	}
+}
+
+func TestAnyMarshalers(t *testing.T) {
+	if field := Any("user", testUser{}); field.Type != ObjectType {
+		t.Errorf("Any with ObjectMarshaler = %s, want %s", field.Type, ObjectType)
+	}
+	if field := Any("roles", testRoles{}); field.Type != ArrayType {
+		t.Errorf("Any with ArrayMarshaler = %s, want %s", field.Type, ArrayType)
+	}
+}
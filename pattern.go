@@ -0,0 +1,320 @@
+package zlog
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/zoobzio/pipz"
+)
+
+// SignalMatcher selects signals for pattern-based routing.
+//
+// Exact routes from Hook are a single map lookup. Pattern routes let one
+// registration cover a whole family of signals instead:
+//
+//	zlog.HookPattern("PAYMENT_*", auditSink)
+//	zlog.HookMatch(zlog.MatchRegexp(regexp.MustCompile(`^(AUTH|SESSION)_`)), securitySink)
+//
+// Matchers are evaluated once per distinct signal - the result is cached as a
+// regular route, so the hot path stays a map lookup after the first event.
+// Match must therefore be deterministic for a given signal, and signal names
+// should come from a bounded set rather than embedding request data: the
+// cache holds at most 1024 signals and is rebuilt when it overflows.
+// Signals no hook receives are evaluated on every event instead of cached.
+//
+// Routes are resolved for the signal an event is emitted with, before any
+// HookAll hooks run.
+type SignalMatcher interface {
+	Match(signal Signal) bool
+}
+
+// gatedMatcher is a SignalMatcher whose hooks drop some of the signals it
+// matches, such as those below the standard level. Enabled asks admits so
+// it does not report signals that are routed only to be dropped.
+type gatedMatcher interface {
+	SignalMatcher
+	admits(signal Signal) bool
+}
+
+// MatchFunc adapts an ordinary function to the SignalMatcher interface.
+//
+//	zlog.HookMatch(zlog.MatchFunc(func(s zlog.Signal) bool {
+//	    return len(s) > 0 && s[0] == 'X'
+//	}), experimentSink)
+type MatchFunc func(signal Signal) bool
+
+// Match implements SignalMatcher.
+func (f MatchFunc) Match(signal Signal) bool {
+	return f(signal)
+}
+
+// MatchPrefix matches signals that start with prefix.
+//
+//	zlog.MatchPrefix("PAYMENT_") // PAYMENT_RECEIVED, PAYMENT_FAILED, ...
+func MatchPrefix(prefix string) SignalMatcher {
+	return MatchFunc(func(signal Signal) bool {
+		return strings.HasPrefix(string(signal), prefix)
+	})
+}
+
+// MatchGlob matches signals against a wildcard pattern where '*' matches any
+// run of characters (including none). All other characters match literally.
+//
+//	zlog.MatchGlob("PAYMENT_*")   // PAYMENT_RECEIVED, PAYMENT_FAILED
+//	zlog.MatchGlob("*_FAILED")    // PAYMENT_FAILED, LOGIN_FAILED
+//	zlog.MatchGlob("USER_*_SYNC") // USER_PROFILE_SYNC
+//	zlog.MatchGlob("*")           // every signal
+func MatchGlob(pattern string) SignalMatcher {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		// No wildcard - plain equality
+		return MatchFunc(func(signal Signal) bool {
+			return string(signal) == pattern
+		})
+	}
+
+	return MatchFunc(func(signal Signal) bool {
+		return matchGlobParts(string(signal), parts)
+	})
+}
+
+// matchGlobParts reports whether s matches the literal parts of a glob that
+// were separated by '*'.
+func matchGlobParts(s string, parts []string) bool {
+	first, last := parts[0], parts[len(parts)-1]
+	if len(s) < len(first)+len(last) || !strings.HasPrefix(s, first) || !strings.HasSuffix(s, last) {
+		return false
+	}
+
+	// Match the middle parts greedily left to right within the remaining span
+	rest := s[len(first) : len(s)-len(last)]
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(rest, part)
+		if idx < 0 {
+			return false
+		}
+		rest = rest[idx+len(part):]
+	}
+	return true
+}
+
+// MatchRegexp matches signals against a compiled regular expression.
+//
+//	zlog.MatchRegexp(regexp.MustCompile(`^(AUTH|SESSION)_`))
+func MatchRegexp(re *regexp.Regexp) SignalMatcher {
+	return MatchFunc(func(signal Signal) bool {
+		return re.MatchString(string(signal))
+	})
+}
+
+// patternEntry is a hook registered against a SignalMatcher.
+type patternEntry[T any] struct {
+	matcher SignalMatcher
+	hookEntry[T]
+}
+
+// HookPattern registers hooks for every signal matching a glob pattern.
+// See MatchGlob for the pattern syntax.
+//
+//	orderLogger.HookPattern("ORDER_*", auditHook)
+func (l *Logger[T]) HookPattern(pattern string, hooks ...pipz.Chainable[Event[T]]) *Logger[T] {
+	return l.HookMatch(MatchGlob(pattern), hooks...)
+}
+
+// HookMatch registers hooks for every signal accepted by the matcher.
+//
+// Pattern hooks run alongside any exact hooks for the same signal, in
+// parallel like other multi-hook routes.
+func (l *Logger[T]) HookMatch(matcher SignalMatcher, hooks ...pipz.Chainable[Event[T]]) *Logger[T] {
+	l.RegisterMatch(matcher, hooks...)
+	return l
+}
+
+// RegisterMatch works like HookMatch but returns a Registration that detaches
+// exactly the hooks added by this call.
+func (l *Logger[T]) RegisterMatch(matcher SignalMatcher, hooks ...pipz.Chainable[Event[T]]) *Registration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ids := make([]uint64, 0, len(hooks))
+	for _, hook := range hooks {
+		l.nextID++
+		l.patterns = append(l.patterns, patternEntry[T]{
+			matcher:   matcher,
+			hookEntry: hookEntry[T]{hook: hook, id: l.nextID},
+		})
+		ids = append(ids, l.nextID)
+		attachHook(hook)
+	}
+	l.reinstallResolved()
+
+	return newRegistration(func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.removePatternHooks(func(entry hookEntry[T]) bool {
+			return containsID(ids, entry.id)
+		})
+	})
+}
+
+// HookUnmatched registers hooks for events whose signal has no exact or
+// pattern route - a catch-all for anything that would otherwise be dropped.
+//
+//	orderLogger.HookUnmatched(unknownSignalHook)
+func (l *Logger[T]) HookUnmatched(hooks ...pipz.Chainable[Event[T]]) *Logger[T] {
+	l.RegisterUnmatched(hooks...)
+	return l
+}
+
+// RegisterUnmatched works like HookUnmatched but returns a Registration that
+// detaches exactly the hooks added by this call.
+func (l *Logger[T]) RegisterUnmatched(hooks ...pipz.Chainable[Event[T]]) *Registration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ids := make([]uint64, 0, len(hooks))
+	for _, hook := range hooks {
+		l.nextID++
+		l.unmatched = append(l.unmatched, hookEntry[T]{hook: hook, id: l.nextID})
+		ids = append(ids, l.nextID)
+		attachHook(hook)
+	}
+	l.reinstallResolved()
+
+	return newRegistration(func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.removePatternHooks(func(entry hookEntry[T]) bool {
+			return containsID(ids, entry.id)
+		})
+	})
+}
+
+// maxResolvedSignals bounds the routes cached for pattern and unmatched
+// hooks, so a stream of distinct signals cannot grow the router without
+// limit. When the cache is full it is emptied and refilled on demand.
+const maxResolvedSignals = 1024
+
+// resolve makes sure pattern and unmatched hooks are installed as a route
+// for the signal. After the first event of a signal this is a read-locked
+// map lookup; loggers without pattern hooks skip the work entirely.
+//
+// Signals that no hook receives are not cached, so they cost a matcher
+// evaluation per event rather than a router entry each.
+func (l *Logger[T]) resolve(signal Signal) {
+	l.mu.RLock()
+	_, done := l.resolved[signal]
+	dynamic := len(l.patterns) > 0 || len(l.unmatched) > 0
+	revision := l.revision
+	var entries []hookEntry[T]
+	if dynamic && !done {
+		entries = l.matchingEntries(signal)
+	}
+	l.mu.RUnlock()
+
+	if done || len(entries) == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, done := l.resolved[signal]; done {
+		return
+	}
+	if len(l.resolved) >= maxResolvedSignals {
+		l.evictResolved()
+	}
+	if l.revision != revision {
+		// Hooks changed since the entries were computed
+		l.installRoute(signal)
+		return
+	}
+	l.resolved[signal] = struct{}{}
+	l.installEntries(signal, entries)
+}
+
+// evictResolved drops the cached routes of signals without exact hooks.
+// They are resolved again by their next event.
+func (l *Logger[T]) evictResolved() {
+	for signal := range l.resolved {
+		delete(l.resolved, signal)
+		if len(l.hooks[signal]) == 0 {
+			l.router.RemoveRoute(signal)
+			delete(l.scaffolds, signal)
+		}
+	}
+}
+
+// routeEntries returns every hook that should receive a signal: its exact
+// hooks plus matching pattern hooks, or the unmatched hooks if there are none.
+func (l *Logger[T]) routeEntries(signal Signal) []hookEntry[T] {
+	exact := l.hooks[signal]
+	if len(l.patterns) == 0 && len(l.unmatched) == 0 {
+		return exact
+	}
+
+	// Exact hooks are part of the cached route too
+	l.resolved[signal] = struct{}{}
+	return l.matchingEntries(signal)
+}
+
+// matchingEntries computes the route for a signal like routeEntries without
+// caching it, so introspection does not change routing state.
+func (l *Logger[T]) matchingEntries(signal Signal) []hookEntry[T] {
+	entries := append([]hookEntry[T](nil), l.hooks[signal]...)
+	for _, pattern := range l.patterns {
+		if pattern.matcher.Match(signal) {
+			entries = append(entries, pattern.hookEntry)
+		}
+	}
+	if len(entries) == 0 {
+		entries = append(entries, l.unmatched...)
+	}
+	return entries
+}
+
+// reinstallResolved rebuilds every cached route after pattern or unmatched
+// hooks change. Signals not seen yet are resolved lazily as events arrive.
+func (l *Logger[T]) reinstallResolved() {
+	l.revision++
+	for signal := range l.resolved {
+		l.installRoute(signal)
+	}
+	for signal := range l.hooks {
+		l.installRoute(signal)
+	}
+}
+
+// removePatternHooks drops pattern and unmatched hooks matching the predicate
+// and rebuilds the affected routes. It reports whether anything was removed.
+func (l *Logger[T]) removePatternHooks(match func(hookEntry[T]) bool) bool {
+	patterns := make([]patternEntry[T], 0, len(l.patterns))
+	for _, entry := range l.patterns {
+		if match(entry.hookEntry) {
+			detachHook(entry.hook)
+		} else {
+			patterns = append(patterns, entry)
+		}
+	}
+
+	unmatched := make([]hookEntry[T], 0, len(l.unmatched))
+	for _, entry := range l.unmatched {
+		if match(entry) {
+			detachHook(entry.hook)
+		} else {
+			unmatched = append(unmatched, entry)
+		}
+	}
+
+	if len(patterns) == len(l.patterns) && len(unmatched) == len(l.unmatched) {
+		return false
+	}
+
+	l.patterns = patterns
+	l.unmatched = unmatched
+	l.reinstallResolved()
+	return true
+}
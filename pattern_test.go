@@ -0,0 +1,224 @@
+package zlog
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		signal  Signal
+		want    bool
+	}{
+		{"PAYMENT_*", "PAYMENT_RECEIVED", true},
+		{"PAYMENT_*", "PAYMENT_", true},
+		{"PAYMENT_*", "REFUND_RECEIVED", false},
+		{"*_FAILED", "LOGIN_FAILED", true},
+		{"*_FAILED", "LOGIN_FAILED_AGAIN", false},
+		{"USER_*_SYNC", "USER_PROFILE_SYNC", true},
+		{"USER_*_SYNC", "USER_SYNC", false},
+		{"A*B*C", "AXXBYYC", true},
+		{"A*B*C", "AXXCYYB", false},
+		{"*", "ANYTHING", true},
+		{"EXACT", "EXACT", true},
+		{"EXACT", "EXACTLY", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchGlob(tt.pattern).Match(tt.signal); got != tt.want {
+			t.Errorf("MatchGlob(%q).Match(%q) = %v, want %v", tt.pattern, tt.signal, got, tt.want)
+		}
+	}
+}
+
+func TestMatchPrefixAndRegexp(t *testing.T) {
+	if !MatchPrefix("AUTH_").Match("AUTH_LOGIN") {
+		t.Error("expected prefix match")
+	}
+	if MatchPrefix("AUTH_").Match("OAUTH_LOGIN") {
+		t.Error("expected prefix mismatch")
+	}
+
+	re := MatchRegexp(regexp.MustCompile(`^(AUTH|SESSION)_`))
+	if !re.Match("SESSION_EXPIRED") || re.Match("PAYMENT_FAILED") {
+		t.Error("unexpected regexp match result")
+	}
+}
+
+func TestHookPattern(t *testing.T) {
+	logger := New()
+
+	var patternCount, exactCount int64
+	patternSink := NewSink("pattern", func(_ context.Context, _ Log) error {
+		atomic.AddInt64(&patternCount, 1)
+		return nil
+	})
+	exactSink := NewSink("exact", func(_ context.Context, _ Log) error {
+		atomic.AddInt64(&exactCount, 1)
+		return nil
+	})
+
+	logger.HookPattern("PAYMENT_*", patternSink)
+	logger.Hook("PAYMENT_FAILED", exactSink)
+
+	logger.Emit("PAYMENT_RECEIVED", "received")
+	logger.Emit("PAYMENT_REFUNDED", "refunded")
+	logger.Emit("SHIPMENT_SENT", "not a payment")
+
+	if got := atomic.LoadInt64(&patternCount); got != 2 {
+		t.Errorf("expected 2 pattern events, got %d", got)
+	}
+
+	// Exact and pattern sinks both receive the event (in parallel)
+	logger.Emit("PAYMENT_FAILED", "failed")
+	if err := logger.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+
+	if got := atomic.LoadInt64(&patternCount); got != 3 {
+		t.Errorf("expected 3 pattern events, got %d", got)
+	}
+	if got := atomic.LoadInt64(&exactCount); got != 1 {
+		t.Errorf("expected 1 exact event, got %d", got)
+	}
+}
+
+func TestHookPatternCachesResolution(t *testing.T) {
+	logger := NewLogger[Fields]()
+
+	var matches int64
+	matcher := MatchFunc(func(signal Signal) bool {
+		atomic.AddInt64(&matches, 1)
+		return signal == "CACHED"
+	})
+	logger.HookMatch(matcher, NewSink("cached", func(_ context.Context, _ Log) error { return nil }))
+
+	for i := 0; i < 10; i++ {
+		logger.Process(NewEvent("CACHED", "cached", nil))
+	}
+
+	if got := atomic.LoadInt64(&matches); got != 1 {
+		t.Errorf("expected matcher to run once per signal, ran %d times", got)
+	}
+}
+
+func TestHookPatternAfterFirstEvent(t *testing.T) {
+	logger := New()
+
+	var count int64
+	sink := NewSink("late", func(_ context.Context, _ Log) error {
+		atomic.AddInt64(&count, 1)
+		return nil
+	})
+
+	// Signal is resolved (to nothing) before the pattern exists
+	logger.Emit("LATE_SIGNAL", "before")
+	reg := logger.HookPattern("LATE_*", sink)
+	logger.Emit("LATE_SIGNAL", "after")
+
+	if got := atomic.LoadInt64(&count); got != 1 {
+		t.Errorf("expected cached route to pick up new pattern, got %d events", got)
+	}
+
+	reg.Remove()
+	logger.Emit("LATE_SIGNAL", "removed")
+	if got := atomic.LoadInt64(&count); got != 1 {
+		t.Errorf("expected no events after removal, got %d", got)
+	}
+}
+
+func TestHookUnmatched(t *testing.T) {
+	logger := New()
+
+	var unmatched, routed int64
+	logger.HookUnmatched(NewSink("unmatched", func(_ context.Context, _ Log) error {
+		atomic.AddInt64(&unmatched, 1)
+		return nil
+	}))
+	logger.Hook(INFO, NewSink("info", func(_ context.Context, _ Log) error {
+		atomic.AddInt64(&routed, 1)
+		return nil
+	}))
+	logger.HookPattern("AUDIT_*", NewSink("audit", func(_ context.Context, _ Log) error {
+		atomic.AddInt64(&routed, 1)
+		return nil
+	}))
+
+	logger.Info("routed exactly")
+	logger.Emit("AUDIT_LOGIN", "routed by pattern")
+	logger.Emit("MYSTERY", "not routed")
+	logger.Emit("ANOTHER_MYSTERY", "not routed")
+
+	if got := atomic.LoadInt64(&routed); got != 2 {
+		t.Errorf("expected 2 routed events, got %d", got)
+	}
+	if got := atomic.LoadInt64(&unmatched); got != 2 {
+		t.Errorf("expected 2 unmatched events, got %d", got)
+	}
+}
+
+func TestUnhookAllRemovesPatternHooks(t *testing.T) {
+	logger := New()
+
+	var count int64
+	sink := NewSink("pattern", func(_ context.Context, _ Log) error {
+		atomic.AddInt64(&count, 1)
+		return nil
+	})
+	logger.HookPattern("GONE_*", sink)
+	logger.HookUnmatched(sink)
+
+	if !logger.UnhookAll(sink) {
+		t.Fatal("expected UnhookAll to remove pattern hooks")
+	}
+
+	logger.Emit("GONE_SIGNAL", "unrouted")
+	logger.Emit("OTHER", "unrouted")
+	if got := atomic.LoadInt64(&count); got != 0 {
+		t.Errorf("expected no events, got %d", got)
+	}
+}
+
+func TestHookPatternMissesNotCached(t *testing.T) {
+	logger := NewLogger[Fields]()
+	logger.HookPattern("PAYMENT_*", NewSink("payments", func(_ context.Context, _ Log) error { return nil }))
+
+	for i := 0; i < 100; i++ {
+		logger.Process(NewEvent(Signal(fmt.Sprintf("OTHER_%d", i)), "miss", nil))
+	}
+
+	logger.mu.RLock()
+	defer logger.mu.RUnlock()
+	if len(logger.resolved) != 0 {
+		t.Errorf("expected signals no hook receives to stay uncached, got %d cached", len(logger.resolved))
+	}
+}
+
+func TestHookUnmatchedResolvedCap(t *testing.T) {
+	logger := NewLogger[Fields]()
+
+	var count int64
+	logger.HookUnmatched(NewSink("unmatched", func(_ context.Context, _ Log) error {
+		atomic.AddInt64(&count, 1)
+		return nil
+	}))
+
+	total := maxResolvedSignals + 100
+	for i := 0; i < total; i++ {
+		logger.Process(NewEvent(Signal(fmt.Sprintf("DYNAMIC_%d", i)), "dynamic", nil))
+	}
+
+	if got := atomic.LoadInt64(&count); got != int64(total) {
+		t.Errorf("expected %d unmatched events, got %d", total, got)
+	}
+
+	logger.mu.RLock()
+	defer logger.mu.RUnlock()
+	if len(logger.resolved) > maxResolvedSignals {
+		t.Errorf("resolved cache grew to %d, want at most %d", len(logger.resolved), maxResolvedSignals)
+	}
+}
@@ -0,0 +1,58 @@
+package zlog
+
+import (
+	"sync"
+
+	"github.com/zoobzio/pipz"
+)
+
+// Registration is a handle to hooks added through Hook, HookAll, or the
+// Register/RegisterAll methods on Logger.
+//
+// Calling Remove detaches exactly the hooks added by that call, regardless of
+// what else has been hooked to the same signal since. This is the most
+// reliable way to undo a registration, especially in tests and when routing
+// is reconfigured at runtime:
+//
+//	reg := zlog.Hook(PAYMENT_RECEIVED, auditSink)
+//	defer reg.Remove()
+type Registration struct {
+	remove func()
+	once   sync.Once
+}
+
+// newRegistration creates a Registration that runs remove at most once.
+func newRegistration(remove func()) *Registration {
+	return &Registration{remove: remove}
+}
+
+// Remove detaches the hooks added by this registration.
+// It is safe to call Remove more than once; only the first call has an effect.
+func (r *Registration) Remove() {
+	if r == nil || r.remove == nil {
+		return
+	}
+	r.once.Do(r.remove)
+}
+
+// containsID reports whether id is in ids.
+func containsID(ids []uint64, id uint64) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+// sameHook reports whether a and b are the same hook.
+// Hooks are compared by identity; values that cannot be compared (such as
+// processors created with pipz.Effect) never match.
+func sameHook[T any](a, b pipz.Chainable[T]) (same bool) {
+	defer func() {
+		if recover() != nil {
+			same = false
+		}
+	}()
+	return a == b
+}
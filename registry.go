@@ -0,0 +1,222 @@
+package zlog
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Severity orders signals by importance.
+//
+// Signals are categorized by meaning, but some tooling still needs to know
+// how serious an event is: minimum-level routing in EnableStandardLogging,
+// console coloring and syslog priorities. Severity provides that ordering
+// without turning signals back into levels.
+//
+// The built-in severities are spaced apart so custom signals can sit between
+// them:
+//
+//	const SeverityNotice = zlog.SeverityInfo + 5
+type Severity int
+
+// Standard severities, matching the built-in logging signals.
+const (
+	// SeverityNone marks a signal that has no severity. Such signals are
+	// never selected by minimum-level routing.
+	SeverityNone Severity = 0
+
+	// SeverityDebug is the severity of DEBUG.
+	SeverityDebug Severity = 10
+
+	// SeverityInfo is the severity of INFO.
+	SeverityInfo Severity = 20
+
+	// SeverityWarn is the severity of WARN.
+	SeverityWarn Severity = 30
+
+	// SeverityError is the severity of ERROR.
+	SeverityError Severity = 40
+
+	// SeverityFatal is the severity of FATAL.
+	SeverityFatal Severity = 50
+)
+
+// String returns the name of the severity. Values between the standard
+// severities are rendered relative to the one below, e.g. "INFO+5".
+func (s Severity) String() string {
+	if s <= SeverityNone {
+		return "NONE"
+	}
+
+	base, name := SeverityDebug, "DEBUG"
+	switch {
+	case s >= SeverityFatal:
+		base, name = SeverityFatal, "FATAL"
+	case s >= SeverityError:
+		base, name = SeverityError, "ERROR"
+	case s >= SeverityWarn:
+		base, name = SeverityWarn, "WARN"
+	case s >= SeverityInfo:
+		base, name = SeverityInfo, "INFO"
+	}
+
+	if s == base {
+		return name
+	}
+	return fmt.Sprintf("%s%+d", name, int(s-base))
+}
+
+// Syslog maps the severity to an RFC 5424 syslog severity (0-7).
+//
+// Values between INFO and WARN map to Notice (5), so a custom NOTICE-style
+// signal lands where syslog users expect it. Anything above FATAL maps to
+// Alert (1). SeverityNone maps to Informational (6).
+func (s Severity) Syslog() int {
+	switch {
+	case s > SeverityFatal:
+		return 1 // Alert
+	case s >= SeverityFatal:
+		return 2 // Critical
+	case s >= SeverityError:
+		return 3 // Error
+	case s >= SeverityWarn:
+		return 4 // Warning
+	case s > SeverityInfo:
+		return 5 // Notice
+	case s >= SeverityInfo, s == SeverityNone:
+		return 6 // Informational
+	default:
+		return 7 // Debug
+	}
+}
+
+// SignalInfo describes a signal in the registry.
+type SignalInfo struct {
+	// Description explains what the signal means and when it is emitted.
+	Description string
+
+	// Tags group related signals, e.g. "payments" or "compliance".
+	Tags []string
+
+	// Severity orders the signal for level-based routing and display.
+	Severity Severity
+}
+
+// signalRegistry holds metadata for known signals.
+type signalRegistry struct {
+	signals map[Signal]SignalInfo
+	mu      sync.RWMutex
+}
+
+var registry = &signalRegistry{
+	signals: map[Signal]SignalInfo{
+		DEBUG: {Severity: SeverityDebug, Description: "Detailed information for diagnosing problems.", Tags: []string{"standard"}},
+		INFO:  {Severity: SeverityInfo, Description: "Informational messages about normal operation.", Tags: []string{"standard"}},
+		WARN:  {Severity: SeverityWarn, Description: "Potentially harmful situations that deserve attention.", Tags: []string{"standard"}},
+		ERROR: {Severity: SeverityError, Description: "Errors that might still allow the application to continue.", Tags: []string{"standard"}},
+		FATAL: {Severity: SeverityFatal, Description: "Severe errors that cause the application to exit.", Tags: []string{"standard"}},
+
+		AUDIT:    {Description: "User actions tracked for compliance and forensics.", Tags: []string{"specialized"}},
+		SECURITY: {Description: "Potential security issues for monitoring systems.", Tags: []string{"specialized"}},
+		METRIC:   {Description: "Measurement data for monitoring.", Tags: []string{"specialized"}},
+	},
+}
+
+// RegisterSignal records metadata for a signal.
+//
+// Registering a severity lets custom signals take part in minimum-level
+// routing, console coloring and syslog severity mapping alongside the
+// built-in signals:
+//
+//	const FRAUD_DETECTED = zlog.Signal("FRAUD_DETECTED")
+//
+//	func init() {
+//	    zlog.RegisterSignal(FRAUD_DETECTED, zlog.SignalInfo{
+//	        Severity:    zlog.SeverityError,
+//	        Description: "A transaction was flagged by the fraud model.",
+//	        Tags:        []string{"payments", "security"},
+//	    })
+//	}
+//
+// Register signals during initialization, before they are first emitted.
+// Registering a signal again replaces its metadata.
+func RegisterSignal(signal Signal, info SignalInfo) {
+	info.Tags = append([]string(nil), info.Tags...)
+
+	registry.mu.Lock()
+	registry.signals[signal] = info
+	registry.mu.Unlock()
+}
+
+// LookupSignal returns the registered metadata for a signal.
+func LookupSignal(signal Signal) (SignalInfo, bool) {
+	registry.mu.RLock()
+	info, ok := registry.signals[signal]
+	registry.mu.RUnlock()
+
+	if ok {
+		info.Tags = append([]string(nil), info.Tags...)
+	}
+	return info, ok
+}
+
+// severityOf returns the registered severity for a signal, or SeverityNone.
+func severityOf(signal Signal) Severity {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return registry.signals[signal].Severity
+}
+
+// Signals returns a snapshot of every registered signal and its metadata,
+// including the built-in signals.
+func Signals() map[Signal]SignalInfo {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	signals := make(map[Signal]SignalInfo, len(registry.signals))
+	for signal, info := range registry.signals {
+		info.Tags = append([]string(nil), info.Tags...)
+		signals[signal] = info
+	}
+	return signals
+}
+
+// WriteSignalDocs writes a Markdown table describing every registered signal,
+// ordered from most to least severe and then by name:
+//
+//	//go:generate go run ./cmd/signaldocs > SIGNALS.md
+//	func main() {
+//	    _ = zlog.WriteSignalDocs(os.Stdout)
+//	}
+func WriteSignalDocs(w io.Writer) error {
+	signals := Signals()
+
+	names := make([]Signal, 0, len(signals))
+	for signal := range signals {
+		names = append(names, signal)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := signals[names[i]], signals[names[j]]
+		if a.Severity != b.Severity {
+			return a.Severity > b.Severity
+		}
+		return names[i] < names[j]
+	})
+
+	var b strings.Builder
+	b.WriteString("| Signal | Severity | Description | Tags |\n")
+	b.WriteString("|--------|----------|-------------|------|\n")
+	for _, signal := range names {
+		info := signals[signal]
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n",
+			signal,
+			info.Severity,
+			strings.ReplaceAll(info.Description, "|", "\\|"),
+			strings.Join(info.Tags, ", "))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
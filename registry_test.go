@@ -0,0 +1,169 @@
+package zlog
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSeverityString(t *testing.T) {
+	tests := []struct {
+		severity Severity
+		want     string
+	}{
+		{SeverityNone, "NONE"},
+		{SeverityDebug, "DEBUG"},
+		{SeverityInfo, "INFO"},
+		{SeverityInfo + 5, "INFO+5"},
+		{SeverityWarn, "WARN"},
+		{SeverityError, "ERROR"},
+		{SeverityFatal, "FATAL"},
+		{SeverityFatal + 10, "FATAL+10"},
+		{SeverityDebug - 5, "DEBUG-5"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.severity.String(); got != tt.want {
+			t.Errorf("Severity(%d).String() = %q, want %q", int(tt.severity), got, tt.want)
+		}
+	}
+}
+
+func TestSeveritySyslog(t *testing.T) {
+	tests := []struct {
+		severity Severity
+		want     int
+	}{
+		{SeverityDebug, 7},
+		{SeverityInfo, 6},
+		{SeverityNone, 6},
+		{SeverityInfo + 5, 5},
+		{SeverityWarn, 4},
+		{SeverityError, 3},
+		{SeverityFatal, 2},
+		{SeverityFatal + 1, 1},
+	}
+
+	for _, tt := range tests {
+		if got := tt.severity.Syslog(); got != tt.want {
+			t.Errorf("%s.Syslog() = %d, want %d", tt.severity, got, tt.want)
+		}
+	}
+}
+
+func TestRegisterSignal(t *testing.T) {
+	signal := Signal("TEST_REGISTRY_SIGNAL")
+	tags := []string{"payments"}
+	RegisterSignal(signal, SignalInfo{
+		Severity:    SeverityError,
+		Description: "Test signal",
+		Tags:        tags,
+	})
+
+	// Mutating the caller's slice must not affect the registry
+	tags[0] = "changed"
+
+	info, ok := LookupSignal(signal)
+	if !ok {
+		t.Fatal("expected signal to be registered")
+	}
+	if info.Severity != SeverityError || info.Description != "Test signal" {
+		t.Errorf("unexpected info: %+v", info)
+	}
+	if len(info.Tags) != 1 || info.Tags[0] != "payments" {
+		t.Errorf("expected tags to be copied, got %v", info.Tags)
+	}
+
+	all := Signals()
+	if _, ok := all[signal]; !ok {
+		t.Error("expected Signals to include registered signal")
+	}
+	if all[ERROR].Severity != SeverityError {
+		t.Error("expected built-in signals to be registered")
+	}
+
+	if _, ok := LookupSignal("TEST_NOT_REGISTERED"); ok {
+		t.Error("expected unknown signal lookup to fail")
+	}
+}
+
+func TestWriteSignalDocs(t *testing.T) {
+	RegisterSignal("TEST_DOCS_SIGNAL", SignalInfo{
+		Severity:    SeverityWarn + 5,
+		Description: "Has a | pipe",
+		Tags:        []string{"a", "b"},
+	})
+
+	var buf bytes.Buffer
+	if err := WriteSignalDocs(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	docs := buf.String()
+
+	if !strings.HasPrefix(docs, "| Signal | Severity | Description | Tags |") {
+		t.Errorf("expected Markdown table header, got: %s", docs)
+	}
+	if !strings.Contains(docs, "| `TEST_DOCS_SIGNAL` | WARN+5 | Has a \\| pipe | a, b |") {
+		t.Errorf("expected custom signal row, got: %s", docs)
+	}
+
+	// Most severe signals come first
+	if strings.Index(docs, "`FATAL`") > strings.Index(docs, "`DEBUG`") {
+		t.Error("expected FATAL to be listed before DEBUG")
+	}
+}
+
+func TestFormatSignalWithSymbolCustomSeverity(t *testing.T) {
+	RegisterSignal("TEST_FRAUD_DETECTED", SignalInfo{Severity: SeverityError})
+
+	result := formatSignalWithSymbol("TEST_FRAUD_DETECTED", false)
+	if result != "[TEST_FRAUD_DETECTED] ✗" {
+		t.Errorf("expected error styling for custom signal, got %q", result)
+	}
+
+	result = formatSignalWithSymbol("TEST_UNREGISTERED", false)
+	if result != "[TEST_UNREGISTERED] •" {
+		t.Errorf("expected default styling for unknown signal, got %q", result)
+	}
+}
+
+func TestEnableStandardLoggingCustomSignals(t *testing.T) {
+	previous := Default()
+	defer SetDefault(previous)
+	SetDefault(New())
+
+	RegisterSignal("TEST_STD_FRAUD", SignalInfo{Severity: SeverityError})
+	RegisterSignal("TEST_STD_CHATTER", SignalInfo{Severity: SeverityDebug})
+
+	old := os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	os.Stderr = w
+	defer func() { os.Stderr = old }()
+
+	EnableStandardLogging(WARN)
+	Emit("TEST_STD_FRAUD", "fraud")
+	Emit("TEST_STD_CHATTER", "chatter")
+	Emit("TEST_STD_UNREGISTERED", "unregistered")
+	Info("below level")
+
+	w.Close()
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	output := buf.String()
+
+	if !strings.Contains(output, `"signal":"TEST_STD_FRAUD"`) {
+		t.Errorf("expected custom ERROR signal to be logged, got: %s", output)
+	}
+	for _, unwanted := range []string{"TEST_STD_CHATTER", "TEST_STD_UNREGISTERED", "below level"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("did not expect %q in output: %s", unwanted, output)
+		}
+	}
+}
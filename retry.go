@@ -32,7 +32,7 @@ func (s *Sink) WithRetry(attempts int) *Sink {
 		attempts = 1
 	}
 
-	return &Sink{
-		processor: pipz.NewRetry("retry", s.processor, attempts),
-	}
+	return s.layer("retry", func(inner pipz.Chainable[Log]) pipz.Chainable[Log] {
+		return pipz.NewRetry("retry", inner, attempts)
+	})
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"math/rand"
 	"sync/atomic"
+
+	"github.com/zoobzio/pipz"
 )
 
 // WithSampling returns a sink adapter that only processes a percentage of events.
@@ -34,8 +36,10 @@ func (s *Sink) WithSampling(rate float64) *Sink {
 	// Clamp rate to valid range
 	if rate <= 0 {
 		// Return a sink that drops everything
-		return NewSink("sampling-drop-all", func(_ context.Context, _ Log) error {
-			return nil
+		return s.layer("sampling", func(pipz.Chainable[Log]) pipz.Chainable[Log] {
+			return pipz.Effect[Log]("sampling-drop-all", func(_ context.Context, _ Log) error {
+				return nil
+			})
 		})
 	}
 	if rate >= 1 {
@@ -46,7 +50,7 @@ func (s *Sink) WithSampling(rate float64) *Sink {
 	// Use a counter for deterministic sampling
 	var counter uint64
 
-	return s.WithFilter(func(_ context.Context, _ Log) bool {
+	return s.filterLayer("sampling", func(_ context.Context, _ Log) bool {
 		// Increment counter atomically
 		count := atomic.AddUint64(&counter, 1)
 
@@ -75,15 +79,17 @@ func (s *Sink) WithSampling(rate float64) *Sink {
 func (s *Sink) WithProbabilisticSampling(rate float64) *Sink {
 	// Clamp rate to valid range
 	if rate <= 0 {
-		return NewSink("probabilistic-drop-all", func(_ context.Context, _ Log) error {
-			return nil
+		return s.layer("sampling", func(pipz.Chainable[Log]) pipz.Chainable[Log] {
+			return pipz.Effect[Log]("probabilistic-drop-all", func(_ context.Context, _ Log) error {
+				return nil
+			})
 		})
 	}
 	if rate >= 1 {
 		return s
 	}
 
-	return s.WithFilter(func(_ context.Context, _ Log) bool {
+	return s.filterLayer("sampling", func(_ context.Context, _ Log) bool {
 		return rand.Float64() < rate //nolint:gosec // Weak random is acceptable for sampling
 	})
 }
@@ -17,10 +17,12 @@ package zlog
 //
 // Signals are just strings, making them easy to create and use. The routing
 // system uses exact string matching to determine which sinks handle each signal.
+// Metadata such as severity and description can be attached with RegisterSignal.
 type Signal string
 
 // Standard logging signals provide compatibility with traditional level-based logging.
-// These signals have implicit severity ordering when used with EnableStandardLogging.
+// These signals are pre-registered with severities (see RegisterSignal), which
+// EnableStandardLogging uses for minimum-level routing.
 const (
 	// DEBUG indicates detailed information for diagnosing problems.
 	// Typically disabled in production.
@@ -2,6 +2,7 @@ package zlog
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/zoobzio/pipz"
@@ -32,17 +33,126 @@ import (
 //	    WithTimeout(30 * time.Second)
 type Sink struct {
 	processor pipz.Chainable[Log]
+	name      pipz.Name       // Name given to NewSink, kept by adapters
+	stats     *statsHold      // When the sink's counters appear in Stats
+	resources []*sinkResource // Flush/close actions, innermost first
 }
 
 // Process delegates to the underlying processor.
 // This makes Sink implement pipz.Chainable[Log].
+// Lazy fields are resolved before the processor sees the event.
+// Failures are passed to the error handler set with SetErrorHandler, and to
+// the dead-letter sink set with SetDeadLetter.
 func (s Sink) Process(ctx context.Context, event Log) (Log, error) {
-	return s.processor.Process(ctx, event)
+	s.stats.claim()
+	event = resolveLazy(event)
+
+	var trace *failureTrace
+	if deadLetterSink.Load() != nil {
+		ctx, trace = traceFailures(ctx)
+	}
+
+	result, err := s.processor.Process(ctx, event)
+	if err != nil {
+		reportSinkError(ctx, s.Name(), event, err)
+		if trace != nil {
+			trace.deliverGlobal(ctx, s.Name(), event, err)
+		}
+	}
+	return result, err
 }
 
-// Name returns the name of the underlying processor.
+// Name returns the name given to NewSink. Adapters such as WithRetry keep
+// the original name, so errors are reported against the sink users know.
 func (s Sink) Name() pipz.Name {
-	return s.processor.Name()
+	if s.name == "" {
+		return s.processor.Name()
+	}
+	return s.name
+}
+
+// wrap returns a new sink around processor that keeps this sink's name and
+// resources, so adapters never lose the ability to flush and close what they wrap.
+func (s *Sink) wrap(processor pipz.Chainable[Log]) *Sink {
+	return s.derive(processor, s.stats.counters, s.resources)
+}
+
+// withResource returns a copy of the sink with an additional resource.
+func (s *Sink) withResource(resource *sinkResource) *Sink {
+	resources := make([]*sinkResource, 0, len(s.resources)+1)
+	resources = append(resources, s.resources...)
+	return s.derive(s.processor, s.stats.counters, append(resources, resource))
+}
+
+// derive builds a new sink with this sink's name around processor. The new
+// sink has its own hold on the given counters, released when it is closed
+// along with the given resources.
+func (s *Sink) derive(processor pipz.Chainable[Log], counters []*sinkCounters, resources []*sinkResource) *Sink {
+	stats := newStatsHold(counters)
+	owned := make([]*sinkResource, 0, len(resources)+1)
+	owned = append(owned, resources...)
+	return &Sink{processor: processor, name: s.name, stats: stats, resources: append(owned, stats.resource())}
+}
+
+// attachStats implements statsHolder.
+func (s Sink) attachStats() {
+	s.stats.attach()
+}
+
+// detachStats implements statsHolder.
+func (s Sink) detachStats() {
+	s.stats.detach()
+}
+
+// sinkResources exposes the sink's resources to the logger during Shutdown.
+func (s Sink) sinkResources() []*sinkResource {
+	return s.resources
+}
+
+// WithFlush attaches a flush action to the sink.
+//
+// Use this for custom sinks that buffer events. The function is called by
+// Flush and during Shutdown, and should return early if the context ends:
+//
+//	buffered := zlog.NewSink("batch", batcher.Add).
+//	    WithFlush(batcher.Flush)
+func (s *Sink) WithFlush(flush func(context.Context) error) *Sink {
+	return s.withResource(&sinkResource{flush: flush})
+}
+
+// WithClose attaches a close action to the sink.
+//
+// Use this for custom sinks that hold files, connections or other resources.
+// The function is called at most once, by Close or during Shutdown:
+//
+//	dbSink := zlog.NewSink("database", writeRow).
+//	    WithClose(func(_ context.Context) error { return db.Close() })
+func (s *Sink) WithClose(closeFn func(context.Context) error) *Sink {
+	return s.withResource(&sinkResource{close: closeFn})
+}
+
+// Flush writes out any events the sink has accepted but not yet delivered,
+// including work queued by WithAsync. Sinks without buffering return nil.
+func (s Sink) Flush(ctx context.Context) error {
+	var errs []error
+	for i := len(s.resources) - 1; i >= 0; i-- {
+		if err := s.resources[i].runFlush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close flushes the sink and releases its resources.
+// Each resource is closed at most once, so calling Close again is harmless.
+func (s Sink) Close(ctx context.Context) error {
+	errs := []error{s.Flush(ctx)}
+	for i := len(s.resources) - 1; i >= 0; i-- {
+		if err := s.resources[i].runClose(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
 
 // NewSink creates a custom sink that processes events.
@@ -77,9 +187,21 @@ func (s Sink) Name() pipz.Name {
 // The returned Sink can be enhanced with capabilities using the fluent API:
 //
 //	sink := zlog.NewSink("example", handler).WithRetry(3)
+//
+// The sink and each adapter count events for Stats, but only appear there
+// while the sink is hooked, or once it processes events directly until it
+// is closed, so sinks built and thrown away never accumulate.
 func NewSink(name string, handler func(context.Context, Log) error) *Sink {
+	counters := &sinkCounters{name: pipz.Name(name)}
+	stats := newStatsHold([]*sinkCounters{counters})
 	return &Sink{
-		processor: pipz.Effect[Log](name, handler),
+		processor: instrumented{
+			next:  pipz.Effect[Log](name, handler),
+			stats: counters,
+		},
+		name:      pipz.Name(name),
+		stats:     stats,
+		resources: []*sinkResource{stats.resource()},
 	}
 }
 
@@ -154,10 +276,11 @@ func (s *Sink) WithRateLimit(config RateLimiterConfig) *Sink {
 		limiter.SetMode("drop")
 	}
 
-	// Wrap the sink's processor with rate limiting
-	return &Sink{
-		processor: pipz.NewSequence[Log]("rate-limited-sink", limiter, s.processor),
-	}
+	// Wrap the sink's processor with rate limiting; rejections are reported
+	// to the error handler as ErrRateLimited
+	return s.layer("rate-limit", func(inner pipz.Chainable[Log]) pipz.Chainable[Log] {
+		return rateLimitGate{limiter: limiter, next: inner}
+	})
 }
 
 // WithCircuitBreaker adds circuit breaker protection to a sink using pipz.NewCircuitBreaker.
@@ -188,18 +311,21 @@ func (s *Sink) WithCircuitBreaker(config CircuitBreakerConfig) *Sink {
 		config.ResetTimeout = 30 * time.Second
 	}
 
-	// Create pipz circuit breaker
-	breaker := pipz.NewCircuitBreaker[Log](
-		s.Name()+" [circuit-breaker]",
-		s.processor,
-		config.FailureThreshold,
-		config.ResetTimeout,
-	)
+	return s.layer("circuit-breaker", func(inner pipz.Chainable[Log]) pipz.Chainable[Log] {
+		// Create pipz circuit breaker. The probe lets trips be reported to the
+		// error handler as ErrCircuitOpen.
+		breaker := pipz.NewCircuitBreaker[Log](
+			s.Name()+" [circuit-breaker]",
+			circuitProbe{next: inner},
+			config.FailureThreshold,
+			config.ResetTimeout,
+		)
 
-	// Set success threshold
-	breaker.SetSuccessThreshold(config.SuccessThreshold)
+		// Set success threshold
+		breaker.SetSuccessThreshold(config.SuccessThreshold)
 
-	return &Sink{processor: breaker}
+		return circuitGuard{breaker: breaker}
+	})
 }
 
 // RateLimitedSink creates a rate-limited sink with sensible defaults.
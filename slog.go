@@ -0,0 +1,258 @@
+package zlog
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// SlogHandler is a slog.Handler that emits records as zlog events.
+//
+// It lets packages written against log/slog share zlog's routing:
+//
+//	slog.SetDefault(slog.New(zlog.NewSlogHandler(nil)))
+//	slog.Info("Cache warmed", "entries", n) // Routed as INFO
+//
+// Record levels become DEBUG, INFO, WARN or ERROR, using the nearest standard
+// level at or below the record's level. A top-level "signal" attribute
+// overrides this, so slog callers can emit domain signals:
+//
+//	slog.Info("Payment processed", "signal", "PAYMENT_PROCESSED", "amount", 99.99)
+//
+// Attributes become fields. Groups are flattened into dotted keys, so
+// slog.Group("http", "status", 200) becomes the field "http.status".
+// Signals chosen with SetStackSignals get a stack trace starting at the slog
+// call, as they do from zlog.Error.
+type SlogHandler struct {
+	logger *FieldsLogger
+	prefix string  // Dotted prefix from WithGroup
+	attrs  []Field // Fields from WithAttrs, already prefixed
+}
+
+// NewSlogHandler creates a slog.Handler that emits through logger.
+// A nil logger emits through the package default logger, following SetDefault.
+func NewSlogHandler(logger *FieldsLogger) *SlogHandler {
+	return &SlogHandler{logger: logger}
+}
+
+// Enabled implements slog.Handler. Every level is enabled; routing decides
+// which sinks see the event.
+func (*SlogHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle implements slog.Handler.
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	signal := slogLevelSignal(record.Level)
+
+	fields := make([]Field, 0, len(h.attrs)+record.NumAttrs())
+	fields = append(fields, h.attrs...)
+	record.Attrs(func(attr slog.Attr) bool {
+		// A top-level signal attribute picks the signal instead of becoming a field
+		if h.prefix == "" && attr.Key == "signal" {
+			if value := attr.Value.Resolve(); value.Kind() == slog.KindString {
+				signal = Signal(value.String())
+				return true
+			}
+		}
+		fields = appendSlogAttr(fields, h.prefix, attr)
+		return true
+	})
+
+	logger := h.logger
+	if logger == nil {
+		logger = Default()
+	}
+
+	var stack *lazyStack
+	if wantsStack(signal, logger.fields, fields) && logger.Enabled(signal) {
+		stack = captureStackAt(record.PC)
+	}
+
+	event := NewEvent(signal, record.Message, eventData(logger.fields, fields, stack))
+	if !record.Time.IsZero() {
+		event.Time = record.Time
+	}
+	event.Caller = callerFromPC(record.PC)
+
+	logger.ProcessContext(ctx, event)
+	return nil
+}
+
+// WithAttrs implements slog.Handler.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	fields := make([]Field, 0, len(h.attrs)+len(attrs))
+	fields = append(fields, h.attrs...)
+	for _, attr := range attrs {
+		fields = appendSlogAttr(fields, h.prefix, attr)
+	}
+	return &SlogHandler{logger: h.logger, prefix: h.prefix, attrs: fields}
+}
+
+// WithGroup implements slog.Handler.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &SlogHandler{logger: h.logger, prefix: h.prefix + name + ".", attrs: h.attrs}
+}
+
+// slogLevelSignal maps a slog level to the nearest standard signal at or below it.
+func slogLevelSignal(level slog.Level) Signal {
+	switch {
+	case level >= slog.LevelError:
+		return ERROR
+	case level >= slog.LevelWarn:
+		return WARN
+	case level >= slog.LevelInfo:
+		return INFO
+	default:
+		return DEBUG
+	}
+}
+
+// appendSlogAttr converts an attribute to fields, flattening groups.
+func appendSlogAttr(fields []Field, prefix string, attr slog.Attr) []Field {
+	value := attr.Value.Resolve()
+	if attr.Key == "" && value.Kind() != slog.KindGroup {
+		return fields // slog handlers ignore empty attributes
+	}
+
+	key := prefix + attr.Key
+	switch value.Kind() {
+	case slog.KindGroup:
+		// Groups with an empty key are inlined, as slog specifies
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix = key + "."
+		}
+		for _, member := range value.Group() {
+			fields = appendSlogAttr(fields, groupPrefix, member)
+		}
+		return fields
+	case slog.KindString:
+		return append(fields, String(key, value.String()))
+	case slog.KindInt64:
+		return append(fields, Int64(key, value.Int64()))
+	case slog.KindUint64:
+		return append(fields, Uint64(key, value.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, Float64(key, value.Float64()))
+	case slog.KindBool:
+		return append(fields, Bool(key, value.Bool()))
+	case slog.KindDuration:
+		return append(fields, Duration(key, value.Duration()))
+	case slog.KindTime:
+		return append(fields, Time(key, value.Time()))
+	default:
+		if err, ok := value.Any().(error); ok {
+			return append(fields, NamedErr(key, err))
+		}
+		return append(fields, Data(key, value.Any()))
+	}
+}
+
+// NewSlogSink creates a sink that forwards events to a slog.Handler, so zlog
+// events can reach handlers written for log/slog:
+//
+//	jsonHandler := slog.NewJSONHandler(os.Stdout, nil)
+//	zlog.HookAll(zlog.NewSlogSink(jsonHandler))
+//
+// Each record carries the event's signal as a "signal" attribute. Signals with
+// a registered severity map to the matching slog level (DEBUG to LevelDebug,
+// FATAL to LevelError+4); other signals use LevelInfo. Fields become attributes
+// of the matching slog kind, and the call site is passed through for handlers
+// with AddSource enabled.
+func NewSlogSink(handler slog.Handler) *Sink {
+	return NewSink("slog", func(ctx context.Context, event Log) error {
+		level := severitySlogLevel(severityOf(event.Signal))
+		if !handler.Enabled(ctx, level) {
+			return nil
+		}
+
+		record := slog.NewRecord(event.Time, level, event.Message, event.Caller.pc)
+		record.AddAttrs(slog.String("signal", string(event.Signal)))
+		for _, field := range event.Data {
+			record.AddAttrs(fieldSlogAttr(field))
+		}
+		return handler.Handle(ctx, record)
+	})
+}
+
+// severitySlogLevel maps a severity onto the slog level scale, where the
+// standard severities are 10 apart and the slog levels 4 apart.
+func severitySlogLevel(severity Severity) slog.Level {
+	if severity == SeverityNone {
+		return slog.LevelInfo
+	}
+	return slog.Level(int(severity-SeverityInfo) * 4 / 10)
+}
+
+// fieldSlogAttr converts a field to a slog attribute based on its type.
+func fieldSlogAttr(field Field) slog.Attr {
+	switch field.Type {
+	case StringType, ErrorType, ByteStringType:
+		if value, ok := field.Value.(string); ok {
+			return slog.String(field.Key, value)
+		}
+	case IntType:
+		if value, ok := field.Value.(int); ok {
+			return slog.Int(field.Key, value)
+		}
+	case Int64Type:
+		if value, ok := field.Value.(int64); ok {
+			return slog.Int64(field.Key, value)
+		}
+	case Int32Type:
+		if value, ok := field.Value.(int32); ok {
+			return slog.Int(field.Key, int(value))
+		}
+	case UintType:
+		if value, ok := field.Value.(uint); ok {
+			return slog.Uint64(field.Key, uint64(value))
+		}
+	case Uint64Type:
+		if value, ok := field.Value.(uint64); ok {
+			return slog.Uint64(field.Key, value)
+		}
+	case Float64Type:
+		if value, ok := field.Value.(float64); ok {
+			return slog.Float64(field.Key, value)
+		}
+	case Float32Type:
+		if value, ok := field.Value.(float32); ok {
+			return slog.Float64(field.Key, float64(value))
+		}
+	case BoolType:
+		if value, ok := field.Value.(bool); ok {
+			return slog.Bool(field.Key, value)
+		}
+	case DurationType:
+		if value, ok := field.Value.(time.Duration); ok {
+			return slog.Duration(field.Key, value)
+		}
+	case TimeType:
+		if value, ok := field.Value.(time.Time); ok {
+			return slog.Time(field.Key, value)
+		}
+	case GroupType, ObjectType, ArrayType:
+		members, nested, err := nestedFields(field)
+		switch {
+		case err != nil:
+			return slog.Any(field.Key, err)
+		case nested && field.Type == ArrayType:
+			return slog.Any(field.Key, jsonFieldValue(field))
+		case nested:
+			attrs := make([]any, len(members))
+			for i, member := range members {
+				attrs[i] = fieldSlogAttr(member)
+			}
+			return slog.Group(field.Key, attrs...)
+		}
+	}
+	return slog.Any(field.Key, field.Value)
+}
@@ -0,0 +1,213 @@
+package zlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSlogHandler(t *testing.T) {
+	logger := New()
+	sink, events := captureSink("slog")
+	logger.HookAll(sink)
+
+	log := slog.New(NewSlogHandler(logger))
+	log.Debug("debug")
+	log.Info("info", "count", 3, "ratio", 0.5, "ok", true)
+	log.Warn("warn", "elapsed", time.Second)
+	log.Error("error", "err", errors.New("boom"))
+	log.Log(context.Background(), slog.LevelWarn+2, "between")
+	log.Info("domain", "signal", "PAYMENT_PROCESSED", "amount", 99.99)
+
+	got := events()
+	want := []Signal{DEBUG, INFO, WARN, ERROR, WARN, "PAYMENT_PROCESSED"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(got))
+	}
+	for i, event := range got {
+		if event.Signal != want[i] {
+			t.Errorf("%q: signal = %s, want %s", event.Message, event.Signal, want[i])
+		}
+		if filepath.Base(event.Caller.File) != "slog_test.go" {
+			t.Errorf("%q: caller = %s, want slog_test.go", event.Message, event.Caller.File)
+		}
+	}
+
+	info := got[1].Data
+	if len(info) != 3 || info[0].Type != Int64Type || info[1].Type != Float64Type || info[2].Type != BoolType {
+		t.Errorf("unexpected info fields: %+v", info)
+	}
+	if f := got[2].Data[0]; f.Type != DurationType || f.Value != time.Second {
+		t.Errorf("unexpected duration field: %+v", f)
+	}
+	if f := got[3].Data[0]; f.Type != ErrorType || fmt.Sprint(f.Value) != "boom" {
+		t.Errorf("unexpected error field: %+v", f)
+	}
+	if domain := got[5].Data; len(domain) != 1 || domain[0].Key != "amount" {
+		t.Errorf("signal attribute should not become a field: %+v", domain)
+	}
+}
+
+func TestSlogHandlerStack(t *testing.T) {
+	logger := New()
+	sink, events := captureSink("slog-stack")
+	logger.HookAll(sink)
+
+	log := slog.New(NewSlogHandler(logger))
+	log.Info("info")
+	log.Error("error", "err", errors.New("boom"))
+
+	got := events()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(got))
+	}
+	if len(got[0].Data) != 0 {
+		t.Errorf("INFO should not carry a stack: %+v", got[0].Data)
+	}
+	data := got[1].Data
+	if len(data) != 2 || data[1].Key != "stack" || data[1].Type != StackType {
+		t.Fatalf("ERROR should carry a stack: %+v", data)
+	}
+	stack, ok := data[1].Resolve().Value.(StackTrace)
+	if !ok || !strings.HasSuffix(stack[0].Function, "TestSlogHandlerStack") {
+		t.Errorf("stack should start at the slog call site: %v", stack)
+	}
+}
+
+func TestSlogHandlerGroups(t *testing.T) {
+	logger := New().With(String("service", "api"))
+	sink, events := captureSink("slog-groups")
+	logger.HookAll(sink)
+
+	log := slog.New(NewSlogHandler(logger)).
+		With("component", "db").
+		WithGroup("query").
+		With("table", "users")
+	log.Info("grouped", "rows", 2, slog.Group("timing", "ms", 5), slog.Group("", "inline", 1), "signal", "NOT_A_SIGNAL")
+
+	got := events()
+	if len(got) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(got))
+	}
+
+	var keys []string
+	for _, field := range got[0].Data {
+		keys = append(keys, field.Key)
+	}
+	want := []string{"service", "component", "query.table", "query.rows", "query.timing.ms", "query.inline", "query.signal"}
+	if len(keys) != len(want) {
+		t.Fatalf("fields = %v, want %v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Errorf("field %d = %s, want %s", i, keys[i], want[i])
+		}
+	}
+	if got[0].Signal != INFO {
+		t.Errorf("grouped signal attribute changed the signal to %s", got[0].Signal)
+	}
+}
+
+func TestFieldSlogAttr(t *testing.T) {
+	attr := fieldSlogAttr(Group("http", Uint("attempt", 2), Int32("status", 200), Float32("ratio", 0.5)))
+	if attr.Value.Kind() != slog.KindGroup {
+		t.Fatalf("group kind = %s", attr.Value.Kind())
+	}
+
+	members := attr.Value.Group()
+	want := []slog.Kind{slog.KindUint64, slog.KindInt64, slog.KindFloat64}
+	if len(members) != len(want) {
+		t.Fatalf("members = %v", members)
+	}
+	for i, member := range members {
+		if member.Value.Kind() != want[i] {
+			t.Errorf("%s kind = %s, want %s", member.Key, member.Value.Kind(), want[i])
+		}
+	}
+}
+
+func TestSlogHandlerDefaultLogger(t *testing.T) {
+	original := defaultLogger
+	defaultLogger = NewLogger[Fields]()
+	t.Cleanup(func() { defaultLogger = original })
+
+	sink, events := captureSink("slog-default")
+	HookAll(sink)
+
+	testKey := contextKey("slog")
+	ctx := ContextWithRequestID(context.WithValue(context.Background(), testKey, "value"), "req-1")
+	slog.New(NewSlogHandler(nil)).InfoContext(ctx, "through default")
+
+	got := events()
+	if len(got) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(got))
+	}
+	if got[0].Data[0].Key != "request_id" {
+		t.Errorf("context extractors were not applied: %+v", got[0].Data)
+	}
+}
+
+func TestNewSlogSink(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug, AddSource: true})
+	sink := NewSlogSink(handler)
+
+	event := NewEvent(WARN, "disk low", []Field{
+		String("mount", "/data"),
+		Int("percent", 91),
+		Duration("elapsed", 2*time.Second),
+		Strings("tags", []string{"a", "b"}),
+	})
+	event.Caller = captureCallerInfo(0)
+
+	if _, err := sink.Process(context.Background(), event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var output map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("failed to parse output %q: %v", buf.String(), err)
+	}
+
+	checks := map[string]any{
+		"level":   "WARN",
+		"msg":     "disk low",
+		"signal":  "WARN",
+		"mount":   "/data",
+		"percent": float64(91),
+		"elapsed": float64(2 * time.Second),
+	}
+	for key, want := range checks {
+		if output[key] != want {
+			t.Errorf("%s = %v, want %v", key, output[key], want)
+		}
+	}
+	source, _ := output["source"].(map[string]any)
+	if file, _ := source["file"].(string); filepath.Base(file) != "slog_test.go" {
+		t.Errorf("source = %v, want slog_test.go", output["source"])
+	}
+}
+
+func TestSeveritySlogLevel(t *testing.T) {
+	tests := map[Severity]slog.Level{
+		SeverityNone:     slog.LevelInfo,
+		SeverityDebug:    slog.LevelDebug,
+		SeverityInfo:     slog.LevelInfo,
+		SeverityInfo + 5: slog.LevelInfo + 2,
+		SeverityWarn:     slog.LevelWarn,
+		SeverityError:    slog.LevelError,
+		SeverityFatal:    slog.LevelError + 4,
+	}
+	for severity, want := range tests {
+		if got := severitySlogLevel(severity); got != want {
+			t.Errorf("severitySlogLevel(%s) = %v, want %v", severity, got, want)
+		}
+	}
+}
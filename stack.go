@@ -0,0 +1,174 @@
+package zlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// maxStackDepth bounds how many frames a stack trace records.
+const maxStackDepth = 64
+
+// Frame is a single call site in a stack trace.
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// StackTrace is a captured call stack, innermost frame first.
+//
+// Stack traces are stored structurally so sinks can render them however
+// suits the output: JSON sinks emit an array of frames and the pretty
+// console sink prints one indented frame per line.
+type StackTrace []Frame
+
+// String renders the stack trace in the familiar panic style.
+func (s StackTrace) String() string {
+	var b strings.Builder
+	for i, frame := range s {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+	}
+	return b.String()
+}
+
+// Stack creates a field holding the stack trace of its call site.
+//
+//	zlog.Warn("Slow query", zlog.Duration("elapsed", d), zlog.Stack("stack"))
+//
+// Only program counters are recorded when the field is created. Frames are
+// looked up the first time the field is resolved, so events that are never
+// formatted cost little; sinks read the StackTrace with Field.Resolve. File
+// paths follow the configured CallerPath. ERROR and FATAL events get a
+// "stack" field automatically; see SetStackSignals.
+func Stack(key string) Field {
+	return Field{Key: key, Type: StackType, Value: captureStack(1)}
+}
+
+// lazyStack is a stack trace recorded as program counters, resolved into
+// frames at most once, on first use.
+type lazyStack struct {
+	pcs    []uintptr
+	frames StackTrace
+	trim   string // Leading frames in this package are dropped when resolved
+	once   sync.Once
+}
+
+// captureStack records the stack starting skip frames above its caller.
+func captureStack(skip int) *lazyStack {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(skip+2, pcs[:]) // Skip runtime.Callers and captureStack
+	return &lazyStack{pcs: append([]uintptr(nil), pcs[:n]...)}
+}
+
+// captureStackAt records the stack of its caller from the frame with the
+// given program counter outward, such as the call site log/slog records.
+// If no frame matches, the whole stack is kept.
+func captureStackAt(pc uintptr) *lazyStack {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(2, pcs[:]) // Skip runtime.Callers and captureStackAt
+	stack := pcs[:n]
+	for i, candidate := range stack {
+		if candidate == pc {
+			stack = stack[i:]
+			break
+		}
+	}
+	return &lazyStack{pcs: append([]uintptr(nil), stack...)}
+}
+
+// resolve returns the frames, looking them up on the first call.
+func (s *lazyStack) resolve() StackTrace {
+	s.once.Do(func() {
+		frames := stackFromPCs(s.pcs)
+		for len(frames) > 1 && s.trim != "" && packagePath(frames[0].Function) == s.trim {
+			frames = frames[1:]
+		}
+		s.frames = frames
+		s.pcs = nil
+	})
+	return s.frames
+}
+
+// String renders the resolved stack trace, for sinks that print values
+// without resolving them first.
+func (s *lazyStack) String() string {
+	return s.resolve().String()
+}
+
+// MarshalJSON encodes the resolved frames.
+func (s *lazyStack) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.resolve())
+}
+
+// stackFromPCs resolves program counters returned by runtime.Callers.
+func stackFromPCs(pcs []uintptr) StackTrace {
+	stack := make(StackTrace, 0, len(pcs))
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, Frame{
+			Function: frame.Function,
+			File:     trimCallerPath(frame.File, frame.Function),
+			Line:     frame.Line,
+		})
+		if !more {
+			break
+		}
+	}
+	return stack
+}
+
+// stackSignals holds the signals that get a stack trace automatically.
+var stackSignals atomic.Pointer[map[Signal]struct{}]
+
+func init() {
+	SetStackSignals(ERROR, FATAL)
+}
+
+// SetStackSignals chooses which signals have a stack trace attached
+// automatically, replacing the previous set. The default is ERROR and FATAL.
+//
+//	zlog.SetStackSignals(zlog.ERROR, zlog.FATAL, PAYMENT_FAILED)
+//
+//	// Disable automatic stack traces
+//	zlog.SetStackSignals()
+//
+// The trace is added as a "stack" field, unless the event already carries a
+// field created with Stack. It is not captured for signals without a route,
+// nor while the caller mode is CallerDisabled.
+func SetStackSignals(signals ...Signal) {
+	set := make(map[Signal]struct{}, len(signals))
+	for _, signal := range signals {
+		set[signal] = struct{}{}
+	}
+	stackSignals.Store(&set)
+}
+
+// wantsStack reports whether events for the signal need an automatic trace,
+// given their bound fields and the fields of the call.
+func wantsStack(signal Signal, bound, fields []Field) bool {
+	if _, ok := (*stackSignals.Load())[signal]; !ok {
+		return false
+	}
+	if CallerMode(callerMode.Load()) == CallerDisabled {
+		return false
+	}
+	for _, field := range bound {
+		if field.Type == StackType {
+			return false
+		}
+	}
+	for _, field := range fields {
+		if field.Type == StackType {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+package zlog
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestStackField(t *testing.T) {
+	field := Stack("trace")
+
+	if field.Key != "trace" || field.Type != StackType {
+		t.Fatalf("unexpected field: %+v", field)
+	}
+	if lazy, ok := field.Value.(*lazyStack); !ok || lazy.frames != nil {
+		t.Fatalf("expected frames to be resolved on demand, got %T", field.Value)
+	}
+	stack, ok := field.Resolve().Value.(StackTrace)
+	if !ok || len(stack) == 0 {
+		t.Fatalf("expected StackTrace value, got %T", field.Value)
+	}
+	if !strings.HasSuffix(stack[0].Function, "TestStackField") {
+		t.Errorf("first frame = %s, want TestStackField", stack[0].Function)
+	}
+	if stack[0].Line == 0 || stack[0].File == "" {
+		t.Errorf("incomplete frame: %+v", stack[0])
+	}
+}
+
+func TestStackTraceJSON(t *testing.T) {
+	stack := StackTrace{{Function: "main.main", File: "main.go", Line: 10}}
+
+	data, err := json.Marshal(map[string]any{"stack": stack})
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	want := `{"stack":[{"function":"main.main","file":"main.go","line":10}]}`
+	if string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
+
+func TestAutomaticStackSignals(t *testing.T) {
+	t.Cleanup(func() { SetStackSignals(ERROR, FATAL) })
+
+	logger := New()
+	sink, events := captureSink("stack")
+	logger.HookAll(sink)
+
+	fields := make([]Field, 1, 4)
+	fields[0] = String("key", "value")
+
+	logger.Info("no stack", fields...)
+	logger.Error("stack", fields...)
+	logger.Error("explicit", Stack("custom"))
+
+	SetStackSignals("PAYMENT_FAILED")
+	logger.Error("disabled")
+	logger.Emit("PAYMENT_FAILED", "custom signal")
+
+	if fields[:2][1].Key != "" {
+		t.Error("caller's field slice was modified")
+	}
+
+	stackKeys := func(event Log) []string {
+		var keys []string
+		for _, field := range event.Data {
+			if field.Type == StackType {
+				keys = append(keys, field.Key)
+			}
+		}
+		return keys
+	}
+
+	got := events()
+	if len(got) != 5 {
+		t.Fatalf("expected 5 events, got %d", len(got))
+	}
+
+	want := [][]string{nil, {"stack"}, {"custom"}, nil, {"stack"}}
+	for i, event := range got {
+		keys := stackKeys(event)
+		if strings.Join(keys, ",") != strings.Join(want[i], ",") {
+			t.Errorf("%q: stack fields = %v, want %v", event.Message, keys, want[i])
+		}
+	}
+
+	stack := got[1].Data[1].Resolve().Value.(StackTrace) //nolint:errcheck // Checked by stackKeys above
+	if !strings.HasSuffix(stack[0].Function, "TestAutomaticStackSignals") {
+		t.Errorf("automatic stack starts at %s, want the call site", stack[0].Function)
+	}
+}
+
+func TestAutomaticStackSkipped(t *testing.T) {
+	t.Cleanup(func() { SetCallerMode(CallerEager) })
+
+	logger := New()
+	sink, events := captureSink("no-stack")
+	logger.Hook(ERROR, sink)
+
+	SetCallerMode(CallerDisabled)
+	logger.Error("disabled")
+
+	for _, event := range events() {
+		for _, field := range event.Data {
+			if field.Type == StackType {
+				t.Errorf("%q: stack captured with CallerDisabled", event.Message)
+			}
+		}
+	}
+
+	// Without a route the stack is not even captured
+	SetCallerMode(CallerEager)
+	withStack := testing.AllocsPerRun(100, func() { logger.Emit(FATAL, "unrouted") })
+	without := testing.AllocsPerRun(100, func() { logger.Emit(INFO, "unrouted") })
+	if withStack != without {
+		t.Errorf("unrouted FATAL allocated %.0f times, INFO %.0f", withStack, without)
+	}
+}
+
+func TestFormatFieldsStack(t *testing.T) {
+	stack := StackTrace{
+		{Function: "main.handler", File: "handler.go", Line: 42},
+		{Function: "main.main", File: "main.go", Line: 10},
+	}
+	output := formatFields([]Field{
+		{Key: "stack", Type: StackType, Value: stack},
+		String("after", "x"),
+	}, false)
+
+	for _, want := range []string{
+		"├─ stack=",
+		"\n   │     main.handler\n   │         handler.go:42",
+		"\n   │     main.main\n   │         main.go:10",
+		"└─ after=x",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
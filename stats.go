@@ -0,0 +1,475 @@
+package zlog
+
+import (
+	"context"
+	"expvar"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zoobzio/pipz"
+)
+
+// latencyBounds are the upper bounds of the latency histogram buckets.
+// Anything slower than the last bound falls into a final overflow bucket.
+var latencyBounds = [...]time.Duration{
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+	10 * time.Second,
+}
+
+// SinkStats is a snapshot of the counters for one sink or adapter layer.
+//
+// Every layer counts the events it received and how each one ended:
+//   - Succeeded: the layer returned without error
+//   - Failed: the layer returned an error after reaching the processor it wraps
+//   - Filtered: WithFilter or sampling chose not to pass the event on
+//   - Dropped: the layer never reached the processor it wraps, because the
+//     event was rate limited or rejected by an open circuit breaker
+//   - Retried: extra attempts made by WithRetry and WithBackoff
+type SinkStats struct {
+	Received  uint64       `json:"received"`
+	Succeeded uint64       `json:"succeeded"`
+	Failed    uint64       `json:"failed"`
+	Filtered  uint64       `json:"filtered"`
+	Dropped   uint64       `json:"dropped"`
+	Retried   uint64       `json:"retried"`
+	Latency   LatencyStats `json:"latency"`
+}
+
+// LatencyStats is a histogram of how long a layer took to process events.
+type LatencyStats struct {
+	Buckets []LatencyBucket `json:"buckets"`
+	Total   time.Duration   `json:"total_ns"`
+	Count   uint64          `json:"count"`
+}
+
+// Mean returns the average processing time, or zero if nothing was recorded.
+func (l LatencyStats) Mean() time.Duration {
+	if l.Count == 0 {
+		return 0
+	}
+	return l.Total / time.Duration(l.Count)
+}
+
+// LatencyBucket counts events that took at most UpperBound, and more than the
+// previous bucket's bound. The last bucket has no upper bound and reports zero.
+type LatencyBucket struct {
+	UpperBound time.Duration `json:"le_ns"`
+	Count      uint64        `json:"count"`
+}
+
+// sinkCounters are the live counters behind a SinkStats snapshot.
+//
+// Counters always count, but only appear in Stats while some sink holds
+// them; see statsHold. Their key is chosen when they enter Stats.
+type sinkCounters struct {
+	owner     *sinkCounters // Handler counters of the sink an adapter layer belongs to
+	name      pipz.Name     // Sink name, or " [kind]" for an adapter layer
+	key       pipz.Name     // Key in Stats while held, guarded by statsRegistry.mu
+	refs      int           // Holds keeping the counters in Stats, guarded by statsRegistry.mu
+	received  atomic.Uint64
+	succeeded atomic.Uint64
+	failed    atomic.Uint64
+	filtered  atomic.Uint64
+	dropped   atomic.Uint64
+	retried   atomic.Uint64
+	total     atomic.Int64
+	buckets   [len(latencyBounds) + 1]atomic.Uint64
+}
+
+// observe records the latency of one event.
+func (c *sinkCounters) observe(elapsed time.Duration) {
+	c.total.Add(int64(elapsed))
+	for i, bound := range latencyBounds {
+		if elapsed <= bound {
+			c.buckets[i].Add(1)
+			return
+		}
+	}
+	c.buckets[len(latencyBounds)].Add(1)
+}
+
+// snapshot copies the counters into a SinkStats.
+func (c *sinkCounters) snapshot() SinkStats {
+	stats := SinkStats{
+		Received:  c.received.Load(),
+		Succeeded: c.succeeded.Load(),
+		Failed:    c.failed.Load(),
+		Filtered:  c.filtered.Load(),
+		Dropped:   c.dropped.Load(),
+		Retried:   c.retried.Load(),
+		Latency: LatencyStats{
+			Buckets: make([]LatencyBucket, len(c.buckets)),
+			Total:   time.Duration(c.total.Load()),
+		},
+	}
+	for i := range c.buckets {
+		count := c.buckets[i].Load()
+		if i < len(latencyBounds) {
+			stats.Latency.Buckets[i].UpperBound = latencyBounds[i]
+		}
+		stats.Latency.Buckets[i].Count = count
+		stats.Latency.Count += count
+	}
+	return stats
+}
+
+// reset zeroes the counters.
+func (c *sinkCounters) reset() {
+	c.received.Store(0)
+	c.succeeded.Store(0)
+	c.failed.Store(0)
+	c.filtered.Store(0)
+	c.dropped.Store(0)
+	c.retried.Store(0)
+	c.total.Store(0)
+	for i := range c.buckets {
+		c.buckets[i].Store(0)
+	}
+}
+
+// statsKey returns the key the counters are reported under, or "" while
+// no sink holds them.
+func (c *sinkCounters) statsKey() pipz.Name {
+	statsRegistry.mu.RLock()
+	defer statsRegistry.mu.RUnlock()
+	return c.key
+}
+
+// statsRegistry holds the counters of every sink and adapter layer that is
+// hooked or in use, by key.
+var statsRegistry = struct {
+	counters map[pipz.Name]*sinkCounters
+	mu       sync.RWMutex
+}{counters: make(map[pipz.Name]*sinkCounters)}
+
+// hold adds a reference to the counters, entering them in Stats on the
+// first. They are keyed by name, or by name plus "#2", "#3" and so on when
+// other counters already use it; layers take their sink's key plus
+// " [kind]". The owner of a layer must already be held. The caller must
+// hold statsRegistry.mu.
+func (c *sinkCounters) hold() {
+	c.refs++
+	if c.refs > 1 {
+		return
+	}
+
+	name := c.name
+	if c.owner != nil {
+		name = c.owner.key + c.name
+	}
+	key := name
+	for n := 2; statsRegistry.counters[key] != nil; n++ {
+		key = name + "#" + pipz.Name(strconv.Itoa(n))
+	}
+	c.key = key
+	statsRegistry.counters[key] = c
+}
+
+// release drops a reference to the counters, removing them from Stats and
+// freeing their key after the last. The caller must hold statsRegistry.mu.
+func (c *sinkCounters) release() {
+	c.refs--
+	if c.refs > 0 {
+		return
+	}
+	if statsRegistry.counters[c.key] == c {
+		delete(statsRegistry.counters, c.key)
+	}
+	c.key = ""
+}
+
+// statsHolder is implemented by hooks whose counters appear in Stats only
+// while they are hooked. Loggers attach hooks as they are added and detach
+// them as they are removed.
+type statsHolder interface {
+	attachStats()
+	detachStats()
+}
+
+// attachHook tells a hook it was added to a logger, see statsHolder.
+func attachHook[T any](hook pipz.Chainable[T]) {
+	if holder, ok := hook.(statsHolder); ok {
+		holder.attachStats()
+	}
+}
+
+// detachHook tells a hook it was removed from a logger, see statsHolder.
+func detachHook[T any](hook pipz.Chainable[T]) {
+	if holder, ok := hook.(statsHolder); ok {
+		holder.detachStats()
+	}
+}
+
+// statsHold decides when the counters of one *Sink, and of everything it
+// wraps, appear in Stats: while the sink is hooked to a logger or, for a
+// sink used without one, from its first event until it is closed. Sinks
+// built ad hoc and never used therefore never enter Stats.
+type statsHold struct {
+	counters []*sinkCounters // Handler counters before the layers they own
+	holds    int             // Hooks, plus one once claimed; guarded by statsRegistry.mu
+	settled  atomic.Bool     // Set once processing no longer needs to claim
+}
+
+// newStatsHold creates the hold for a sink built on the given counters.
+func newStatsHold(counters []*sinkCounters) *statsHold {
+	return &statsHold{counters: counters}
+}
+
+// add takes one hold, entering the counters in Stats on the first. The
+// caller must hold statsRegistry.mu.
+func (h *statsHold) add() {
+	h.holds++
+	if h.holds > 1 {
+		return
+	}
+	for _, counters := range h.counters {
+		counters.hold()
+	}
+}
+
+// drop gives up one hold, removing the counters from Stats after the last.
+// The caller must hold statsRegistry.mu.
+func (h *statsHold) drop() {
+	if h.holds == 0 {
+		return
+	}
+	h.holds--
+	if h.holds > 0 {
+		return
+	}
+	for i := len(h.counters) - 1; i >= 0; i-- {
+		h.counters[i].release()
+	}
+}
+
+// attach holds the counters while the sink is hooked. Hooked sinks are
+// never claimed by processing, so unhooking them removes their counters.
+func (h *statsHold) attach() {
+	statsRegistry.mu.Lock()
+	defer statsRegistry.mu.Unlock()
+
+	h.settled.Store(true)
+	h.add()
+}
+
+// detach gives up the hold taken by attach.
+func (h *statsHold) detach() {
+	statsRegistry.mu.Lock()
+	defer statsRegistry.mu.Unlock()
+
+	h.drop()
+}
+
+// claim holds the counters of a sink that processes events without having
+// been hooked, until it is closed. After the first call it is one atomic
+// load.
+func (h *statsHold) claim() {
+	if h.settled.Load() {
+		return
+	}
+
+	statsRegistry.mu.Lock()
+	defer statsRegistry.mu.Unlock()
+
+	if !h.settled.Swap(true) {
+		h.add()
+	}
+}
+
+// nest marks the sink as part of another sink, whose hold covers its
+// counters, so processing events for the outer sink does not claim it.
+func (h *statsHold) nest() {
+	h.settled.Store(true)
+}
+
+// resource returns a sink resource that gives up every hold when the sink
+// is closed, directly or through Shutdown.
+func (h *statsHold) resource() *sinkResource {
+	return &sinkResource{close: func(context.Context) error {
+		statsRegistry.mu.Lock()
+		defer statsRegistry.mu.Unlock()
+
+		h.settled.Store(true)
+		if h.holds > 0 {
+			h.holds = 1
+			h.drop()
+		}
+		return nil
+	}}
+}
+
+// Stats returns a snapshot of the counters for every sink and adapter layer.
+//
+// Sinks are keyed by the name given to NewSink and adapter layers by the sink
+// name plus the adapter, so a sink built as
+//
+//	zlog.NewSink("http", send).WithRetry(3).WithTimeout(5 * time.Second)
+//
+// reports under "http", "http [retry]" and "http [timeout]". Every sink has
+// its own counters: when several sinks in Stats share a name, later ones
+// report under "http#2", "http#2 [retry]" and so on, in the order they
+// entered Stats. DescribeTopology shows the key of each layer.
+//
+// A sink enters Stats when it is hooked and leaves when it is unhooked,
+// closed or its logger shut down. A sink used without a logger, through its
+// Process method, enters with its first event and leaves when it is closed.
+// Sinks that are built but never used do not appear at all.
+//
+//	for name, stats := range zlog.Stats() {
+//	    fmt.Printf("%s: %d received, %d failed, mean %s\n",
+//	        name, stats.Received, stats.Failed, stats.Latency.Mean())
+//	}
+func Stats() map[pipz.Name]SinkStats {
+	statsRegistry.mu.RLock()
+	defer statsRegistry.mu.RUnlock()
+
+	stats := make(map[pipz.Name]SinkStats, len(statsRegistry.counters))
+	for name, counters := range statsRegistry.counters {
+		stats[name] = counters.snapshot()
+	}
+	return stats
+}
+
+// ResetStats clears every counter. Mostly useful in tests.
+func ResetStats() {
+	statsRegistry.mu.RLock()
+	defer statsRegistry.mu.RUnlock()
+
+	for _, counters := range statsRegistry.counters {
+		counters.reset()
+	}
+}
+
+// PublishExpvar publishes Stats through the expvar package under the given
+// name, so they appear at /debug/vars alongside the runtime's variables:
+//
+//	zlog.PublishExpvar("zlog")
+//	http.ListenAndServe(":6060", nil) // GET /debug/vars
+//
+// Publishing the same name again has no effect.
+func PublishExpvar(name string) {
+	if expvar.Get(name) != nil {
+		return
+	}
+	expvar.Publish(name, expvar.Func(func() any {
+		stats := Stats()
+		named := make(map[string]SinkStats, len(stats))
+		for sink, s := range stats {
+			named[string(sink)] = s
+		}
+		return named
+	}))
+}
+
+// layerCallKey carries the attempt counter for the innermost instrumented
+// layer processing an event.
+type layerCallKey struct{}
+
+// instrumented counts events passing through one sink or adapter layer.
+//
+// For adapter layers, the processor being wrapped is an attemptProbe, which
+// reports back through the context how often the adapter called it. That is
+// how drops (never called) and retries (called more than once) are told apart
+// from ordinary successes and failures.
+type instrumented struct {
+	next    pipz.Chainable[Log]
+	stats   *sinkCounters
+	adapter bool
+	filters bool // Events the adapter does not pass on were filtered out
+
+	// Adapter layers remember what they wrap so DescribeTopology can walk
+	// the chain; pipz connectors do not expose their children.
+	kind   string
+	inner  pipz.Chainable[Log]
+	branch pipz.Chainable[Log]
+}
+
+// Process implements pipz.Chainable.
+func (i instrumented) Process(ctx context.Context, event Log) (Log, error) {
+	i.stats.received.Add(1)
+
+	var attempts *atomic.Int32
+	if i.adapter {
+		attempts = new(atomic.Int32)
+		ctx = context.WithValue(ctx, layerCallKey{}, attempts)
+	}
+
+	start := time.Now()
+	result, err := i.next.Process(ctx, event)
+	i.stats.observe(time.Since(start))
+
+	if !i.adapter {
+		recordAttempt(ctx, err)
+	}
+
+	switch {
+	case attempts != nil && attempts.Load() == 0 && i.filters:
+		i.stats.filtered.Add(1)
+	case attempts != nil && attempts.Load() == 0:
+		i.stats.dropped.Add(1)
+	case err != nil:
+		i.stats.failed.Add(1)
+	default:
+		i.stats.succeeded.Add(1)
+	}
+	if attempts != nil && attempts.Load() > 1 {
+		i.stats.retried.Add(uint64(attempts.Load() - 1))
+	}
+	return result, err
+}
+
+// Name implements pipz.Chainable.
+func (i instrumented) Name() pipz.Name {
+	return i.next.Name()
+}
+
+// attemptProbe counts calls from an adapter to the processor it wraps.
+type attemptProbe struct {
+	next pipz.Chainable[Log]
+}
+
+// Process implements pipz.Chainable.
+func (p attemptProbe) Process(ctx context.Context, event Log) (Log, error) {
+	if attempts, ok := ctx.Value(layerCallKey{}).(*atomic.Int32); ok {
+		attempts.Add(1)
+	}
+	return p.next.Process(ctx, event)
+}
+
+// Name implements pipz.Chainable.
+func (p attemptProbe) Name() pipz.Name {
+	return p.next.Name()
+}
+
+// layer wraps the sink in an adapter with its own statistics. The build
+// function receives the processor to wrap and returns the adapter.
+func (s *Sink) layer(kind string, build func(inner pipz.Chainable[Log]) pipz.Chainable[Log]) *Sink {
+	return s.branchLayer(kind, nil, build)
+}
+
+// branchLayer works like layer for adapters that can also send events to a
+// second processor, such as WithFallback.
+func (s *Sink) branchLayer(kind string, branch pipz.Chainable[Log], build func(inner pipz.Chainable[Log]) pipz.Chainable[Log]) *Sink {
+	adapter := build(attemptProbe{next: s.processor})
+	stats := &sinkCounters{owner: s.stats.counters[0], name: " [" + pipz.Name(kind) + "]"}
+
+	counters := make([]*sinkCounters, 0, len(s.stats.counters)+1)
+	counters = append(counters, s.stats.counters...)
+	counters = append(counters, stats)
+
+	return s.derive(instrumented{
+		next:    adapter,
+		stats:   stats,
+		adapter: true,
+		filters: kind == "filter" || kind == "sampling",
+		kind:    kind,
+		inner:   s.processor,
+		branch:  branch,
+	}, counters, s.resources)
+}
@@ -0,0 +1,234 @@
+package zlog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/zoobzio/pipz"
+)
+
+// closeSinks closes sinks when the test ends, freeing their Stats keys.
+func closeSinks(t *testing.T, sinks ...*Sink) {
+	t.Helper()
+	t.Cleanup(func() {
+		for _, sink := range sinks {
+			_ = sink.Close(context.Background()) //nolint:errcheck // Test cleanup
+		}
+	})
+}
+
+func TestSinkStats(t *testing.T) {
+	calls := 0
+	sink := NewSink("stats-flaky", func(_ context.Context, _ Log) error {
+		calls++
+		if calls%2 == 1 {
+			return errors.New("first attempt fails")
+		}
+		return nil
+	}).WithRetry(3).WithFilter(func(_ context.Context, event Log) bool {
+		return event.Signal != DEBUG
+	})
+	closeSinks(t, sink)
+
+	ctx := context.Background()
+	_, _ = sink.Process(ctx, NewEvent(INFO, "one", nil))   //nolint:errcheck // Checked through stats
+	_, _ = sink.Process(ctx, NewEvent(INFO, "two", nil))   //nolint:errcheck // Checked through stats
+	_, _ = sink.Process(ctx, NewEvent(DEBUG, "skip", nil)) //nolint:errcheck // Checked through stats
+
+	stats := Stats()
+
+	filter := stats["stats-flaky [filter]"]
+	if filter.Received != 3 || filter.Succeeded != 2 || filter.Filtered != 1 || filter.Dropped != 0 {
+		t.Errorf("filter stats = %+v", filter)
+	}
+
+	retry := stats["stats-flaky [retry]"]
+	if retry.Received != 2 || retry.Succeeded != 2 || retry.Retried != 2 || retry.Failed != 0 {
+		t.Errorf("retry stats = %+v", retry)
+	}
+
+	base := stats["stats-flaky"]
+	if base.Received != 4 || base.Succeeded != 2 || base.Failed != 2 {
+		t.Errorf("base stats = %+v", base)
+	}
+	if base.Latency.Count != 4 {
+		t.Errorf("latency count = %d, want 4", base.Latency.Count)
+	}
+}
+
+func TestSinkStatsDrops(t *testing.T) {
+	ok := func(_ context.Context, _ Log) error { return nil }
+	limited := NewSink("stats-limited", ok).
+		WithRateLimit(RateLimiterConfig{RequestsPerSecond: 0.001, BurstSize: 1})
+	sampled := NewSink("stats-sampled", ok).WithSampling(0)
+	breaker := NewSink("stats-breaker", func(_ context.Context, _ Log) error { return errors.New("down") }).
+		WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, ResetTimeout: time.Hour})
+	closeSinks(t, limited, sampled, breaker)
+
+	ctx := context.Background()
+	event := NewEvent(INFO, "msg", nil)
+	for i := 0; i < 3; i++ {
+		_, _ = limited.Process(ctx, event) //nolint:errcheck // Checked through stats
+		_, _ = sampled.Process(ctx, event) //nolint:errcheck // Checked through stats
+		_, _ = breaker.Process(ctx, event) //nolint:errcheck // Checked through stats
+	}
+
+	stats := Stats()
+	tests := map[string]SinkStats{
+		"stats-limited [rate-limit]":      {Received: 3, Succeeded: 1, Dropped: 2},
+		"stats-sampled [sampling]":        {Received: 3, Filtered: 3},
+		"stats-breaker [circuit-breaker]": {Received: 3, Failed: 1, Dropped: 2},
+	}
+	for name, want := range tests {
+		got := stats[pipz.Name(name)]
+		if got.Received != want.Received || got.Succeeded != want.Succeeded ||
+			got.Failed != want.Failed || got.Filtered != want.Filtered || got.Dropped != want.Dropped {
+			t.Errorf("%s = %+v, want %+v", name, got, want)
+		}
+	}
+}
+
+func TestLatencyHistogram(t *testing.T) {
+	var counters sinkCounters
+	counters.observe(50 * time.Microsecond)
+	counters.observe(5 * time.Millisecond)
+	counters.observe(time.Minute)
+
+	stats := counters.snapshot()
+	if stats.Latency.Count != 3 {
+		t.Fatalf("count = %d, want 3", stats.Latency.Count)
+	}
+
+	buckets := stats.Latency.Buckets
+	if buckets[0].Count != 1 || buckets[2].Count != 1 || buckets[len(buckets)-1].Count != 1 {
+		t.Errorf("unexpected buckets: %+v", buckets)
+	}
+	if buckets[len(buckets)-1].UpperBound != 0 {
+		t.Errorf("overflow bucket should have no bound: %+v", buckets[len(buckets)-1])
+	}
+	if mean := stats.Latency.Mean(); mean != (50*time.Microsecond+5*time.Millisecond+time.Minute)/3 {
+		t.Errorf("mean = %s", mean)
+	}
+}
+
+func TestPublishExpvar(t *testing.T) {
+	sink := NewSink("stats-expvar", func(_ context.Context, _ Log) error { return nil })
+	closeSinks(t, sink)
+	_, _ = sink.Process(context.Background(), NewEvent(INFO, "msg", nil)) //nolint:errcheck // Test
+
+	PublishExpvar("zlog-test")
+	PublishExpvar("zlog-test") // Must not panic
+
+	variable := expvar.Get("zlog-test")
+	if variable == nil {
+		t.Fatal("expvar not published")
+	}
+
+	var published map[string]SinkStats
+	if err := json.Unmarshal([]byte(variable.String()), &published); err != nil {
+		t.Fatalf("invalid expvar JSON: %v", err)
+	}
+	if published["stats-expvar"].Received != 1 {
+		t.Errorf("published stats = %+v", published["stats-expvar"])
+	}
+}
+
+func TestResetStats(t *testing.T) {
+	sink := NewSink("stats-reset", func(_ context.Context, _ Log) error { return nil })
+	closeSinks(t, sink)
+	_, _ = sink.Process(context.Background(), NewEvent(INFO, "msg", nil)) //nolint:errcheck // Test
+
+	ResetStats()
+	if got := Stats()["stats-reset"]; got.Received != 0 {
+		t.Errorf("stats not reset: %+v", got)
+	}
+
+	_, _ = sink.Process(context.Background(), NewEvent(INFO, "msg", nil)) //nolint:errcheck // Test
+	if got := Stats()["stats-reset"]; got.Received != 1 {
+		t.Errorf("sink stopped counting after reset: %+v", got)
+	}
+}
+
+func TestSinkStatsPerInstance(t *testing.T) {
+	ctx := context.Background()
+	ok := func(_ context.Context, _ Log) error { return nil }
+	first := NewSink("stats-shared", ok).WithRetry(2)
+	second := NewSink("stats-shared", ok).WithRetry(2)
+	closeSinks(t, first, second)
+
+	_, _ = first.Process(ctx, NewEvent(INFO, "msg", nil))  //nolint:errcheck // Checked through stats
+	_, _ = second.Process(ctx, NewEvent(INFO, "msg", nil)) //nolint:errcheck // Checked through stats
+	_, _ = second.Process(ctx, NewEvent(INFO, "msg", nil)) //nolint:errcheck // Checked through stats
+
+	stats := Stats()
+	for name, want := range map[pipz.Name]uint64{
+		"stats-shared":           1,
+		"stats-shared [retry]":   1,
+		"stats-shared#2":         2,
+		"stats-shared#2 [retry]": 2,
+	} {
+		if got := stats[name].Received; got != want {
+			t.Errorf("%s received %d, want %d", name, got, want)
+		}
+	}
+
+	if err := first.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	stats = Stats()
+	if _, ok := stats["stats-shared"]; ok {
+		t.Error("closed sink still reported")
+	}
+	if _, ok := stats["stats-shared [retry]"]; ok {
+		t.Error("closed sink's layer still reported")
+	}
+	if stats["stats-shared#2"].Received != 2 {
+		t.Errorf("open sink lost its stats: %+v", stats["stats-shared#2"])
+	}
+
+	third := NewSink("stats-shared", ok)
+	closeSinks(t, third)
+	_, _ = third.Process(ctx, NewEvent(INFO, "msg", nil)) //nolint:errcheck // Checked through stats
+	if got := Stats()["stats-shared"].Received; got != 1 {
+		t.Errorf("freed key not reused, received %d", got)
+	}
+}
+
+func TestSinkStatsFollowHooks(t *testing.T) {
+	ok := func(_ context.Context, _ Log) error { return nil }
+	sink := NewSink("stats-hooked", ok).WithRetry(2)
+	_ = sink.WithFilter(func(_ context.Context, _ Log) bool { return true }) // Built and dropped
+
+	stats := Stats()
+	if _, ok := stats["stats-hooked"]; ok {
+		t.Error("sink reported before it was hooked or used")
+	}
+
+	logger := New()
+	reg := logger.Hook(INFO, sink)
+	logger.Hook(WARN, sink)
+	stats = Stats()
+	for _, name := range []pipz.Name{"stats-hooked", "stats-hooked [retry]"} {
+		if _, ok := stats[name]; !ok {
+			t.Errorf("hooked sink missing %s", name)
+		}
+	}
+	if _, ok := stats["stats-hooked [filter]"]; ok {
+		t.Error("unused adapter reported")
+	}
+
+	reg.Remove()
+	if _, ok := Stats()["stats-hooked"]; !ok {
+		t.Error("sink still hooked to WARN was removed from Stats")
+	}
+
+	logger.Unhook(WARN, sink)
+	logger.Info("after unhook")
+	if _, ok := Stats()["stats-hooked"]; ok {
+		t.Error("unhooked sink still reported")
+	}
+}
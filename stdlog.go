@@ -0,0 +1,129 @@
+package zlog
+
+import (
+	"log"
+	"strconv"
+	"strings"
+)
+
+// stdLogFlags is used for loggers writing into zlog. zlog records the time
+// itself, so only the call site is requested from the log package.
+const stdLogFlags = log.Llongfile
+
+// RedirectStdLog sends output from the standard library's log package through
+// zlog as events with the given signal, routed by the default logger.
+//
+// Third-party libraries that call log.Printf then follow the same routing as
+// the rest of the application. RedirectStdLog changes the log package's flags
+// so each event gets the correct call site; the returned function restores the
+// previous output, flags and prefix:
+//
+//	restore := zlog.RedirectStdLog(zlog.INFO)
+//	defer restore()
+//
+//	log.Printf("connected to %s", addr) // INFO event, caller at this line
+func RedirectStdLog(signal Signal) func() {
+	logger := log.Default()
+	output, flags, prefix := logger.Writer(), logger.Flags(), logger.Prefix()
+
+	logger.SetOutput(&stdLogWriter{logger: logger, signal: signal})
+	logger.SetFlags(stdLogFlags)
+	logger.SetPrefix("")
+
+	return func() {
+		logger.SetOutput(output)
+		logger.SetFlags(flags)
+		logger.SetPrefix(prefix)
+	}
+}
+
+// NewStdLogger creates a *log.Logger whose output becomes zlog events with the
+// given signal and fields, routed by the default logger. Use it for libraries
+// that accept a *log.Logger:
+//
+//	server := &http.Server{
+//	    ErrorLog: zlog.NewStdLogger(zlog.ERROR, zlog.String("component", "http")),
+//	}
+//
+// The logger's flags and prefix may be changed; the date, time and prefix are
+// removed from the message, and file and line become the event's caller.
+// Signals chosen with SetStackSignals get a stack trace starting at the log
+// call, here and with RedirectStdLog.
+func NewStdLogger(signal Signal, fields ...Field) *log.Logger {
+	writer := &stdLogWriter{signal: signal, fields: append([]Field(nil), fields...)}
+	writer.logger = log.New(writer, "", stdLogFlags)
+	return writer.logger
+}
+
+// stdLogWriter turns each write from a *log.Logger into an event.
+type stdLogWriter struct {
+	logger *log.Logger
+	signal Signal
+	fields []Field
+}
+
+// Write implements io.Writer. The log package calls Write once per entry.
+func (w *stdLogWriter) Write(p []byte) (int, error) {
+	msg, caller := parseStdLogLine(string(p), w.logger.Flags(), w.logger.Prefix())
+
+	logger := currentLogger()
+	var stack *lazyStack
+	if wantsStack(w.signal, w.fields, nil) && logger.Enabled(w.signal) {
+		// The log package's own frames are dropped when the stack is resolved
+		stack = captureStack(1)
+		stack.trim = "log"
+	}
+
+	event := NewEvent(w.signal, msg, eventData(w.fields, nil, stack))
+	if CallerMode(callerMode.Load()) != CallerDisabled {
+		event.Caller = caller
+	}
+	logger.Process(event)
+	return len(p), nil
+}
+
+// parseStdLogLine strips the header the log package writes for the given
+// flags and prefix, returning the message and the call site if present.
+func parseStdLogLine(line string, flags int, prefix string) (string, CallerInfo) {
+	line = strings.TrimSuffix(line, "\n")
+
+	if flags&log.Lmsgprefix == 0 {
+		line = strings.TrimPrefix(line, prefix)
+	}
+	if flags&log.Ldate != 0 {
+		line = cutStdLogHeader(line, len("2009/01/23 "))
+	}
+	if flags&(log.Ltime|log.Lmicroseconds) != 0 {
+		width := len("01:23:23 ")
+		if flags&log.Lmicroseconds != 0 {
+			width = len("01:23:23.123123 ")
+		}
+		line = cutStdLogHeader(line, width)
+	}
+
+	var caller CallerInfo
+	if flags&(log.Lshortfile|log.Llongfile) != 0 {
+		if end := strings.Index(line, ": "); end > 0 {
+			location := line[:end]
+			if colon := strings.LastIndex(location, ":"); colon > 0 {
+				if lineNo, err := strconv.Atoi(location[colon+1:]); err == nil {
+					caller = CallerInfo{File: trimCallerPath(location[:colon], ""), Line: lineNo}
+					line = line[end+2:]
+				}
+			}
+		}
+	}
+
+	if flags&log.Lmsgprefix != 0 {
+		line = strings.TrimPrefix(line, prefix)
+	}
+	return line, caller
+}
+
+// cutStdLogHeader removes a fixed-width header field if the line is long enough.
+func cutStdLogHeader(line string, width int) string {
+	if len(line) < width {
+		return line
+	}
+	return line[width:]
+}
@@ -0,0 +1,139 @@
+package zlog
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRedirectStdLog(t *testing.T) {
+	original := defaultLogger
+	defaultLogger = NewLogger[Fields]()
+	t.Cleanup(func() { defaultLogger = original })
+
+	sink, events := captureSink("stdlog")
+	HookAll(sink)
+
+	var previous bytes.Buffer
+	log.SetOutput(&previous)
+	log.SetFlags(log.LstdFlags)
+	log.SetPrefix("app: ")
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+		log.SetPrefix("")
+	})
+
+	restore := RedirectStdLog(WARN)
+	log.Printf("connected to %s", "db")
+	_, _, line, _ := runtime.Caller(0)
+	restore()
+
+	log.Print("after restore")
+
+	got := events()
+	if len(got) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(got))
+	}
+	if got[0].Signal != WARN || got[0].Message != "connected to db" {
+		t.Errorf("unexpected event: %s %q", got[0].Signal, got[0].Message)
+	}
+	if filepath.Base(got[0].Caller.File) != "stdlog_test.go" || got[0].Caller.Line != line-1 {
+		t.Errorf("caller = %s:%d, want stdlog_test.go:%d", got[0].Caller.File, got[0].Caller.Line, line-1)
+	}
+
+	if log.Flags() != log.LstdFlags || log.Prefix() != "app: " {
+		t.Errorf("flags/prefix not restored: %d %q", log.Flags(), log.Prefix())
+	}
+	if !bytes.Contains(previous.Bytes(), []byte("app: ")) || !bytes.Contains(previous.Bytes(), []byte("after restore")) {
+		t.Errorf("output not restored: %q", previous.String())
+	}
+}
+
+func TestNewStdLogger(t *testing.T) {
+	original := defaultLogger
+	defaultLogger = NewLogger[Fields]()
+	t.Cleanup(func() { defaultLogger = original })
+
+	sink, events := captureSink("stdlogger")
+	HookAll(sink)
+
+	logger := NewStdLogger(ERROR, String("component", "http"))
+	logger.Println("handshake failed")
+
+	logger.SetFlags(log.LstdFlags | log.Lmicroseconds | log.Lshortfile | log.Lmsgprefix)
+	logger.SetPrefix("[tls] ")
+	logger.Println("second")
+
+	got := events()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(got))
+	}
+	for _, event := range got {
+		if event.Signal != ERROR {
+			t.Errorf("signal = %s, want ERROR", event.Signal)
+		}
+		if filepath.Base(event.Caller.File) != "stdlog_test.go" {
+			t.Errorf("%q: caller = %s, want stdlog_test.go", event.Message, event.Caller.File)
+		}
+		if len(event.Data) == 0 || event.Data[0].Key != "component" {
+			t.Errorf("%q: missing bound field: %v", event.Message, event.Data)
+		}
+	}
+	if got[0].Message != "handshake failed" || got[1].Message != "second" {
+		t.Errorf("messages = %q, %q", got[0].Message, got[1].Message)
+	}
+
+	// ERROR events get a stack starting at the log call, as from zlog.Error
+	data := got[0].Data
+	if len(data) != 2 || data[1].Type != StackType {
+		t.Fatalf("missing automatic stack: %v", data)
+	}
+	stack, ok := data[1].Resolve().Value.(StackTrace)
+	if !ok || !strings.HasSuffix(stack[0].Function, "TestNewStdLogger") {
+		t.Errorf("stack should start at the log call site: %v", stack)
+	}
+}
+
+func TestParseStdLogLine(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		prefix string
+		msg    string
+		file   string
+		flags  int
+		lineNo int
+	}{
+		{name: "bare", line: "hello\n", msg: "hello"},
+		{name: "prefix", line: "app: hello\n", prefix: "app: ", msg: "hello"},
+		{name: "std flags", line: "2009/01/23 01:23:23 hello\n", flags: log.LstdFlags, msg: "hello"},
+		{
+			name: "everything", line: "app: 2009/01/23 01:23:23.123123 /src/main.go:42: hello: world\n",
+			flags: log.LstdFlags | log.Lmicroseconds | log.Llongfile, prefix: "app: ",
+			msg: "hello: world", file: "/src/main.go", lineNo: 42,
+		},
+		{
+			name: "message prefix", line: "main.go:7: app: hello\n",
+			flags: log.Lshortfile | log.Lmsgprefix, prefix: "app: ",
+			msg: "hello", file: "main.go", lineNo: 7,
+		},
+		{name: "missing location", line: "hello\n", flags: log.Lshortfile, msg: "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, caller := parseStdLogLine(tt.line, tt.flags, tt.prefix)
+			if msg != tt.msg {
+				t.Errorf("msg = %q, want %q", msg, tt.msg)
+			}
+			if caller.File != tt.file || caller.Line != tt.lineNo {
+				t.Errorf("caller = %s:%d, want %s:%d", caller.File, caller.Line, tt.file, tt.lineNo)
+			}
+		})
+	}
+}
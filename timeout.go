@@ -41,7 +41,7 @@ func (s *Sink) WithTimeout(duration time.Duration) *Sink {
 		duration = 30 * time.Second // Default timeout
 	}
 
-	return &Sink{
-		processor: pipz.NewTimeout("timeout", s.processor, duration),
-	}
+	return s.layer("timeout", func(inner pipz.Chainable[Log]) pipz.Chainable[Log] {
+		return pipz.NewTimeout("timeout", inner, duration)
+	})
 }
@@ -0,0 +1,333 @@
+package zlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/zoobzio/pipz"
+)
+
+// SinkInfo describes a hook or sink and the processors it is built from.
+//
+// For a sink built as
+//
+//	zlog.NewSink("api", send).WithRetry(3).WithTimeout(time.Second).WithFilter(keep)
+//
+// Layers lists filter, timeout and retry - outermost first, in the order an
+// event passes through them - and Handler is "api".
+type SinkInfo struct {
+	// Name is the name given to NewSink, or the hook's pipz name.
+	Name pipz.Name `json:"name"`
+
+	// Layers are the adapters wrapped around the handler, outermost first.
+	Layers []LayerInfo `json:"layers,omitempty"`
+
+	// Handler is the name of the innermost processor.
+	Handler pipz.Name `json:"handler"`
+}
+
+// LayerInfo describes one adapter in a sink's processor chain.
+type LayerInfo struct {
+	// Kind is the adapter type, e.g. "retry", "filter" or "circuit-breaker".
+	Kind string `json:"kind"`
+
+	// Name is the key the layer's statistics are reported under in Stats.
+	Name pipz.Name `json:"name"`
+
+	// Fallback describes the sink that receives events the wrapped chain
+	// fails on. It is only set for "fallback" and "dead-letter" layers.
+	Fallback *SinkInfo `json:"fallback,omitempty"`
+}
+
+// Topology is a snapshot of a logger's routing.
+type Topology struct {
+	// Routes maps each routed signal to the sinks that receive it.
+	Routes map[Signal][]SinkInfo `json:"routes"`
+
+	// Global lists the HookAll sinks that see every event, in order.
+	Global []SinkInfo `json:"global,omitempty"`
+
+	// Unmatched lists the HookUnmatched sinks for signals with no other route.
+	Unmatched []SinkInfo `json:"unmatched,omitempty"`
+}
+
+// TopologyFormat selects the output of DescribeTopology.
+type TopologyFormat string
+
+// Supported topology formats.
+const (
+	// TopologyText renders one line per route, readable in a terminal.
+	TopologyText TopologyFormat = "text"
+
+	// TopologyJSON renders the Topology as indented JSON.
+	TopologyJSON TopologyFormat = "json"
+
+	// TopologyDOT renders a Graphviz graph, e.g. for `dot -Tsvg`.
+	TopologyDOT TopologyFormat = "dot"
+)
+
+// Routes returns the sinks each signal is delivered to, including sinks
+// added through HookPattern and HookMatch.
+//
+// Pattern sinks are listed for every signal seen so far and every signal in
+// the registry (see RegisterSignal); the matchers themselves are opaque.
+func (l *Logger[T]) Routes() map[Signal][]SinkInfo {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.routes()
+}
+
+// routes builds the Routes map. The caller must hold l.mu.
+func (l *Logger[T]) routes() map[Signal][]SinkInfo {
+	signals := make(map[Signal]struct{}, len(l.hooks)+len(l.resolved))
+	for signal := range l.hooks {
+		signals[signal] = struct{}{}
+	}
+	if len(l.patterns) > 0 {
+		for signal := range l.resolved {
+			signals[signal] = struct{}{}
+		}
+		for signal := range Signals() {
+			signals[signal] = struct{}{}
+		}
+	}
+
+	routes := make(map[Signal][]SinkInfo, len(signals))
+	for signal := range signals {
+		entries := l.matchingEntries(signal)
+		if len(entries) == 0 || isUnmatched(entries, l.unmatched) {
+			continue
+		}
+		routes[signal] = describeEntries(entries)
+	}
+	return routes
+}
+
+// GlobalHooks describes the HookAll hooks in the order they run.
+func (l *Logger[T]) GlobalHooks() []SinkInfo {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return describeEntries(l.globals)
+}
+
+// Topology returns a snapshot of the logger's routes, HookAll hooks and
+// HookUnmatched hooks.
+func (l *Logger[T]) Topology() Topology {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return Topology{
+		Routes:    l.routes(),
+		Global:    describeEntries(l.globals),
+		Unmatched: describeEntries(l.unmatched),
+	}
+}
+
+// Routes returns the sinks each signal is delivered to by the default logger.
+//
+//	for _, sink := range zlog.Routes()[zlog.SECURITY] {
+//	    fmt.Println(sink.Name)
+//	}
+func Routes() map[Signal][]SinkInfo {
+	return currentLogger().Routes()
+}
+
+// GlobalHooks describes the sinks registered with HookAll, in order.
+func GlobalHooks() []SinkInfo {
+	return currentLogger().GlobalHooks()
+}
+
+// DescribeTopology writes the default logger's routing in the given format,
+// for debugging or generated documentation:
+//
+//	zlog.DescribeTopology(os.Stdout, zlog.TopologyText)
+//
+//	// go run ./cmd/topology | dot -Tsvg > routing.svg
+//	zlog.DescribeTopology(os.Stdout, zlog.TopologyDOT)
+func DescribeTopology(w io.Writer, format TopologyFormat) error {
+	return currentLogger().Topology().Write(w, format)
+}
+
+// Write renders the topology in the given format.
+func (t Topology) Write(w io.Writer, format TopologyFormat) error {
+	var b strings.Builder
+	switch format {
+	case TopologyText:
+		t.writeText(&b)
+	case TopologyDOT:
+		t.writeDOT(&b)
+	case TopologyJSON:
+		data, err := json.MarshalIndent(t, "", "  ")
+		if err != nil {
+			return err
+		}
+		b.Write(data)
+		b.WriteByte('\n')
+	default:
+		return fmt.Errorf("unknown topology format %q", format)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeText renders one line per sink, grouped by route:
+//
+//	ERROR
+//	  api: filter -> retry -> api
+func (t Topology) writeText(b *strings.Builder) {
+	section := func(title string, sinks []SinkInfo) {
+		if len(sinks) == 0 {
+			return
+		}
+		b.WriteString(title)
+		b.WriteByte('\n')
+		for _, sink := range sinks {
+			fmt.Fprintf(b, "  %s: %s\n", sink.Name, sink.chain())
+		}
+	}
+
+	section("HookAll", t.Global)
+	for _, signal := range sortedSignals(t.Routes) {
+		section(string(signal), t.Routes[signal])
+	}
+	section("Unmatched", t.Unmatched)
+}
+
+// chain renders the sink's layers and handler as a single arrow-separated line.
+func (s SinkInfo) chain() string {
+	parts := make([]string, 0, len(s.Layers)+1)
+	for _, layer := range s.Layers {
+		part := layer.Kind
+		if layer.Fallback != nil {
+			part = fmt.Sprintf("%s(%s)", layer.Kind, layer.Fallback.chain())
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(append(parts, string(s.Handler)), " -> ")
+}
+
+// writeDOT renders a left-to-right graph with one box per processor.
+// Signals are ellipses; fallback branches hang off their layer.
+func (t Topology) writeDOT(b *strings.Builder) {
+	b.WriteString("digraph zlog {\n  rankdir=LR;\n  node [shape=box];\n")
+
+	nodes := 0
+	var chain func(from string, sink SinkInfo, label string)
+	chain = func(from string, sink SinkInfo, label string) {
+		edge := ""
+		if label != "" {
+			edge = fmt.Sprintf(" [label=%q]", label)
+		}
+		for _, layer := range sink.Layers {
+			nodes++
+			id := fmt.Sprintf("n%d", nodes)
+			fmt.Fprintf(b, "  %s [label=%q];\n  %s -> %s%s;\n", id, layer.Kind, from, id, edge)
+			if layer.Fallback != nil {
+				chain(id, *layer.Fallback, "fallback")
+			}
+			from, edge = id, ""
+		}
+		nodes++
+		id := fmt.Sprintf("n%d", nodes)
+		fmt.Fprintf(b, "  %s [label=%q, style=bold];\n  %s -> %s%s;\n", id, string(sink.Handler), from, id, edge)
+	}
+
+	route := func(id, label string, sinks []SinkInfo) {
+		if len(sinks) == 0 {
+			return
+		}
+		fmt.Fprintf(b, "  %s [label=%q, shape=ellipse];\n", id, label)
+		for _, sink := range sinks {
+			chain(id, sink, "")
+		}
+	}
+
+	route("global", "HookAll", t.Global)
+	for i, signal := range sortedSignals(t.Routes) {
+		route(fmt.Sprintf("s%d", i), string(signal), t.Routes[signal])
+	}
+	route("unmatched", "Unmatched", t.Unmatched)
+
+	b.WriteString("}\n")
+}
+
+// sortedSignals returns the routed signals in name order.
+func sortedSignals(routes map[Signal][]SinkInfo) []Signal {
+	signals := make([]Signal, 0, len(routes))
+	for signal := range routes {
+		signals = append(signals, signal)
+	}
+	sort.Slice(signals, func(i, j int) bool { return signals[i] < signals[j] })
+	return signals
+}
+
+// isUnmatched reports whether a route is only the unmatched fallback, which
+// Topology lists separately.
+func isUnmatched[T any](entries, unmatched []hookEntry[T]) bool {
+	return len(unmatched) > 0 && len(entries) == len(unmatched) && entries[0].id == unmatched[0].id
+}
+
+// describeEntries describes each hook in order.
+func describeEntries[T any](entries []hookEntry[T]) []SinkInfo {
+	if len(entries) == 0 {
+		return nil
+	}
+	infos := make([]SinkInfo, len(entries))
+	for i, entry := range entries {
+		infos[i] = describeHook(entry.hook)
+	}
+	return infos
+}
+
+// describeHook describes a hook, walking the processor chain of sinks.
+// Other hooks are opaque and described by name alone.
+func describeHook[T any](hook pipz.Chainable[Event[T]]) SinkInfo {
+	sink, ok := any(hook).(*Sink)
+	if !ok {
+		return SinkInfo{Name: hook.Name(), Handler: hook.Name()}
+	}
+
+	info := describeChain(sink.processor)
+	info.Name = sink.Name()
+	return info
+}
+
+// describeChain follows the layers zlog wraps around a handler.
+func describeChain(processor pipz.Chainable[Log]) SinkInfo {
+	var info SinkInfo
+	for {
+		switch p := processor.(type) {
+		case instrumented:
+			if !p.adapter {
+				processor = p.next
+				continue
+			}
+			layer := LayerInfo{Kind: p.kind, Name: p.stats.statsKey()}
+			if p.branch != nil {
+				fallback := describeChain(p.branch)
+				layer.Fallback = &fallback
+			}
+			info.Layers = append(info.Layers, layer)
+			processor = p.inner
+
+		case asyncLayer:
+			info.Layers = append(info.Layers, LayerInfo{Kind: "async"})
+			processor = p.inner
+
+		case Sink:
+			processor = p.processor
+
+		case *Sink:
+			processor = p.processor
+
+		default:
+			info.Name = processor.Name()
+			info.Handler = processor.Name()
+			return info
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package zlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zoobzio/pipz"
+)
+
+func useTopologyLogger(t *testing.T) {
+	t.Helper()
+	original := defaultLogger
+	defaultLogger = NewLogger[Fields]()
+	t.Cleanup(func() { defaultLogger = original })
+}
+
+func noopSink(name string) *Sink {
+	return NewSink(name, func(_ context.Context, _ Log) error { return nil })
+}
+
+func layerKinds(info SinkInfo) []string {
+	kinds := make([]string, len(info.Layers))
+	for i, layer := range info.Layers {
+		kinds[i] = layer.Kind
+	}
+	return kinds
+}
+
+func TestRoutesDescribeSinkChain(t *testing.T) {
+	useTopologyLogger(t)
+
+	api := noopSink("api").
+		WithRetry(3).
+		WithTimeout(time.Second).
+		WithFilter(func(_ context.Context, _ Log) bool { return true })
+	Hook(ERROR, api, noopSink("file"))
+
+	routes := Routes()
+	sinks := routes[ERROR]
+	if len(sinks) != 2 {
+		t.Fatalf("expected 2 sinks for ERROR, got %+v", routes)
+	}
+
+	got := sinks[0]
+	if got.Name != "api" || got.Handler != "api" {
+		t.Errorf("unexpected sink info: %+v", got)
+	}
+	if kinds := layerKinds(got); !reflect.DeepEqual(kinds, []string{"filter", "timeout", "retry"}) {
+		t.Errorf("layers = %v", kinds)
+	}
+	if name := got.Layers[2].Name; !strings.HasPrefix(string(name), "api") || !strings.HasSuffix(string(name), " [retry]") {
+		t.Errorf("layer name = %q", name)
+	} else if _, ok := Stats()[name]; !ok {
+		t.Errorf("layer name %q is not a Stats key", name)
+	}
+	if len(sinks[1].Layers) != 0 || sinks[1].Handler != "file" {
+		t.Errorf("unexpected plain sink info: %+v", sinks[1])
+	}
+}
+
+func TestRoutesSeeThroughWrappers(t *testing.T) {
+	useTopologyLogger(t)
+
+	backup := noopSink("backup").WithRetry(2)
+	sink := noopSink("primary").
+		WithCircuitBreaker(CircuitBreakerConfig{}).
+		WithRateLimit(RateLimiterConfig{RequestsPerSecond: 10}).
+		WithFallback(backup).
+		WithAsync()
+	Hook(SECURITY, sink)
+
+	got := Routes()[SECURITY][0]
+	want := []string{"async", "fallback", "rate-limit", "circuit-breaker"}
+	if kinds := layerKinds(got); !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("layers = %v, want %v", kinds, want)
+	}
+
+	fallback := got.Layers[1].Fallback
+	if fallback == nil || fallback.Name != "backup" || !reflect.DeepEqual(layerKinds(*fallback), []string{"retry"}) {
+		t.Errorf("unexpected fallback: %+v", fallback)
+	}
+}
+
+func TestRoutesIncludePatterns(t *testing.T) {
+	useTopologyLogger(t)
+
+	HookPattern("SEC*", noopSink("security"))
+	HookUnmatched(noopSink("unrouted"))
+	Hook(INFO, noopSink("console"))
+
+	resolved := len(currentLogger().resolved)
+	routes := Routes()
+	if len(routes[SECURITY]) != 1 || routes[SECURITY][0].Name != "security" {
+		t.Errorf("SECURITY route = %+v", routes[SECURITY])
+	}
+	if _, ok := routes[DEBUG]; ok {
+		t.Error("unmatched fallback should not be listed as a route")
+	}
+
+	// Introspection must not install routes
+	if len(currentLogger().resolved) != resolved {
+		t.Error("Routes changed the resolved route cache")
+	}
+
+	topology := currentLogger().Topology()
+	if len(topology.Unmatched) != 1 || topology.Unmatched[0].Name != "unrouted" {
+		t.Errorf("unmatched = %+v", topology.Unmatched)
+	}
+}
+
+func TestGlobalHooks(t *testing.T) {
+	useTopologyLogger(t)
+
+	HookAll(noopSink("first"), noopSink("second").WithSampling(0.5))
+	currentLogger().HookAll(pipz.Effect[Log]("plain", func(_ context.Context, _ Log) error { return nil }))
+
+	hooks := GlobalHooks()
+	if len(hooks) != 3 {
+		t.Fatalf("expected 3 global hooks, got %+v", hooks)
+	}
+	if hooks[1].Name != "second" || !reflect.DeepEqual(layerKinds(hooks[1]), []string{"sampling"}) {
+		t.Errorf("unexpected second hook: %+v", hooks[1])
+	}
+	if hooks[2].Name != "plain" || hooks[2].Handler != "plain" {
+		t.Errorf("unexpected plain hook: %+v", hooks[2])
+	}
+}
+
+func TestDescribeTopology(t *testing.T) {
+	useTopologyLogger(t)
+
+	HookAll(noopSink("audit"))
+	Hook(ERROR, noopSink("api").WithRetry(3).WithFallback(noopSink("disk")))
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := DescribeTopology(&buf, TopologyText); err != nil {
+			t.Fatal(err)
+		}
+		want := "HookAll\n  audit: audit\nERROR\n  api: fallback(disk) -> retry -> api\n"
+		if buf.String() != want {
+			t.Errorf("text =\n%s\nwant\n%s", buf.String(), want)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := DescribeTopology(&buf, TopologyJSON); err != nil {
+			t.Fatal(err)
+		}
+		var topology Topology
+		if err := json.Unmarshal(buf.Bytes(), &topology); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if !reflect.DeepEqual(topology, currentLogger().Topology()) {
+			t.Errorf("JSON round trip mismatch: %s", buf.String())
+		}
+	})
+
+	t.Run("dot", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := DescribeTopology(&buf, TopologyDOT); err != nil {
+			t.Fatal(err)
+		}
+		out := buf.String()
+		for _, want := range []string{"digraph zlog {", `label="ERROR"`, `label="retry"`, `[label="fallback"]`, `label="disk"`} {
+			if !strings.Contains(out, want) {
+				t.Errorf("DOT output missing %s:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		if err := DescribeTopology(&bytes.Buffer{}, "yaml"); err == nil {
+			t.Error("expected error for unknown format")
+		}
+	})
+}
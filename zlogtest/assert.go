@@ -0,0 +1,183 @@
+package zlogtest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zoobzio/zlog"
+)
+
+// Matcher selects recorded events in assertions.
+type Matcher interface {
+	// Match reports whether the event is selected.
+	Match(event zlog.Log) bool
+
+	// String describes the matcher in failure messages.
+	String() string
+}
+
+// matcher adapts a function and description to Matcher.
+type matcher struct {
+	match       func(zlog.Log) bool
+	description string
+}
+
+// Match implements Matcher.
+func (m matcher) Match(event zlog.Log) bool {
+	return m.match(event)
+}
+
+// String implements Matcher.
+func (m matcher) String() string {
+	return m.description
+}
+
+// Field matches events with a field of the given key and value. Values are
+// compared with reflect.DeepEqual, so the types must match as well:
+//
+//	zlogtest.Field("status", 200)       // zlog.Int("status", 200)
+//	zlogtest.Field("id", int64(200))    // zlog.Int64("id", 200)
+//
+// Error fields match an error with errors.Is, or a string with the error's
+// message:
+//
+//	zlogtest.Field("error", sql.ErrNoRows)
+//	zlogtest.Field("error", "connection refused")
+func Field(key string, value any) Matcher {
+	return matcher{
+		match: func(event zlog.Log) bool {
+			for _, field := range event.Data {
+				if field.Key == key && valueMatches(field.Value, value) {
+					return true
+				}
+			}
+			return false
+		},
+		description: fmt.Sprintf("%s=%v", key, value),
+	}
+}
+
+// valueMatches compares a field value with an expected value.
+func valueMatches(got, want any) bool {
+	if err, ok := got.(error); ok {
+		switch want := want.(type) {
+		case error:
+			return errors.Is(err, want)
+		case string:
+			return err.Error() == want
+		}
+	}
+	return reflect.DeepEqual(got, want)
+}
+
+// HasField matches events with a field of the given key, whatever its value.
+func HasField(key string) Matcher {
+	return matcher{
+		match: func(event zlog.Log) bool {
+			for _, field := range event.Data {
+				if field.Key == key {
+					return true
+				}
+			}
+			return false
+		},
+		description: "has " + key,
+	}
+}
+
+// Message matches events with exactly the given message.
+func Message(message string) Matcher {
+	return matcher{
+		match:       func(event zlog.Log) bool { return event.Message == message },
+		description: fmt.Sprintf("message %q", message),
+	}
+}
+
+// MessageContains matches events whose message contains substr.
+func MessageContains(substr string) Matcher {
+	return matcher{
+		match:       func(event zlog.Log) bool { return strings.Contains(event.Message, substr) },
+		description: fmt.Sprintf("message containing %q", substr),
+	}
+}
+
+// AssertEmitted fails the test unless an event with the signal matching
+// every matcher was recorded. It returns the first such event.
+//
+//	event := rec.AssertEmitted(t, zlog.ERROR, zlogtest.HasField("error"))
+func (r *Recorder) AssertEmitted(t testing.TB, signal zlog.Signal, matchers ...Matcher) zlog.Log {
+	t.Helper()
+
+	events := r.Events()
+	for _, event := range events {
+		if event.Signal == signal && matchAll(event, matchers) {
+			return event
+		}
+	}
+
+	t.Errorf("zlogtest: no %s event%s\nrecorded:%s", signal, describeMatchers(matchers), describeEvents(events))
+	return zlog.Log{}
+}
+
+// AssertNoSignal fails the test if any event with the signal was recorded.
+func (r *Recorder) AssertNoSignal(t testing.TB, signal zlog.Signal) {
+	t.Helper()
+
+	if events := r.Signal(signal); len(events) > 0 {
+		t.Errorf("zlogtest: unexpected %s events:%s", signal, describeEvents(events))
+	}
+}
+
+// AssertEmitted works like Recorder.AssertEmitted on the recorder installed
+// by Isolate.
+func AssertEmitted(t testing.TB, signal zlog.Signal, matchers ...Matcher) zlog.Log {
+	t.Helper()
+	return isolated(t).AssertEmitted(t, signal, matchers...)
+}
+
+// AssertNoSignal works like Recorder.AssertNoSignal on the recorder
+// installed by Isolate.
+func AssertNoSignal(t testing.TB, signal zlog.Signal) {
+	t.Helper()
+	isolated(t).AssertNoSignal(t, signal)
+}
+
+// matchAll reports whether the event satisfies every matcher.
+func matchAll(event zlog.Log, matchers []Matcher) bool {
+	for _, m := range matchers {
+		if !m.Match(event) {
+			return false
+		}
+	}
+	return true
+}
+
+// describeMatchers renders matchers for a failure message.
+func describeMatchers(matchers []Matcher) string {
+	if len(matchers) == 0 {
+		return ""
+	}
+	parts := make([]string, len(matchers))
+	for i, m := range matchers {
+		parts[i] = m.String()
+	}
+	return " with " + strings.Join(parts, ", ")
+}
+
+// describeEvents renders recorded events one per line for a failure message.
+func describeEvents(events []zlog.Log) string {
+	if len(events) == 0 {
+		return " none"
+	}
+	var b strings.Builder
+	for _, event := range events {
+		fmt.Fprintf(&b, "\n  [%s] %s", event.Signal, event.Message)
+		for _, field := range event.Data {
+			fmt.Fprintf(&b, " %s=%v", field.Key, field.Value)
+		}
+	}
+	return b.String()
+}
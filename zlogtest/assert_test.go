@@ -0,0 +1,83 @@
+package zlogtest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/zoobzio/zlog"
+)
+
+// fakeT records assertion failures instead of failing the test.
+type fakeT struct {
+	testing.TB
+	failures []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertEmitted(t *testing.T) {
+	rec := NewRecorder()
+	logger := zlog.New()
+	logger.HookAll(rec.Sink)
+	logger.Info("user created", zlog.String("user", "ada"), zlog.Int("age", 36))
+
+	event := rec.AssertEmitted(t, zlog.INFO,
+		Field("user", "ada"),
+		Field("age", 36),
+		HasField("user"),
+		Message("user created"),
+		MessageContains("created"))
+	if event.Message != "user created" {
+		t.Errorf("unexpected event returned: %+v", event)
+	}
+
+	fake := &fakeT{}
+	rec.AssertEmitted(fake, zlog.INFO, Field("age", int64(36)))
+	rec.AssertEmitted(fake, zlog.ERROR)
+	if len(fake.failures) != 2 {
+		t.Fatalf("expected 2 failures, got %v", fake.failures)
+	}
+	if !strings.Contains(fake.failures[0], "age=36") || !strings.Contains(fake.failures[0], "[INFO] user created") {
+		t.Errorf("failure should describe matchers and recorded events: %s", fake.failures[0])
+	}
+}
+
+func TestAssertNoSignal(t *testing.T) {
+	rec := NewRecorder()
+	logger := zlog.New()
+	logger.HookAll(rec.Sink)
+	logger.Warn("disk almost full")
+
+	rec.AssertNoSignal(t, zlog.ERROR)
+
+	fake := &fakeT{}
+	rec.AssertNoSignal(fake, zlog.WARN)
+	if len(fake.failures) != 1 || !strings.Contains(fake.failures[0], "disk almost full") {
+		t.Errorf("unexpected failures: %v", fake.failures)
+	}
+}
+
+func TestFieldMatchesErrors(t *testing.T) {
+	errNotFound := errors.New("not found")
+
+	rec := NewRecorder()
+	logger := zlog.New()
+	logger.HookAll(rec.Sink)
+	logger.Error("lookup failed", zlog.Err(fmt.Errorf("user 7: %w", errNotFound)))
+
+	rec.AssertEmitted(t, zlog.ERROR, Field("error", errNotFound))
+	rec.AssertEmitted(t, zlog.ERROR, Field("error", "user 7: not found"))
+
+	fake := &fakeT{}
+	rec.AssertEmitted(fake, zlog.ERROR, Field("error", errors.New("not found")))
+	rec.AssertEmitted(fake, zlog.ERROR, Field("error", "not found"))
+	if len(fake.failures) != 2 {
+		t.Errorf("expected 2 failures, got %v", fake.failures)
+	}
+}
@@ -0,0 +1,106 @@
+package zlogtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zoobzio/zlog"
+)
+
+// update rewrites golden files instead of comparing against them:
+//
+//	go test ./... -zlogtest.update
+var update = flag.Bool("zlogtest.update", false, "rewrite zlogtest golden files")
+
+// goldenEvent is the stable encoding of a recorded event. Time and caller
+// change between runs and are left out.
+type goldenEvent struct {
+	Signal  string        `json:"signal"`
+	Message string        `json:"message"`
+	Fields  []goldenField `json:"fields,omitempty"`
+}
+
+// goldenField is a field in goldenEvent, keeping its type.
+type goldenField struct {
+	Key   string `json:"key"`
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+// goldenStack replaces stack traces in golden files. Their paths, line
+// numbers and runtime frames differ between machines and Go versions.
+const goldenStack = "[stack trace]"
+
+// Encode renders the recorded events as newline-delimited JSON suitable for
+// golden files: signal, message and fields in order, without the time and
+// caller that change between runs. Stack trace fields, such as the one added
+// to ERROR events, are kept but their value is replaced with
+// "[stack trace]".
+func (r *Recorder) Encode() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, event := range r.Events() {
+		golden := goldenEvent{Signal: string(event.Signal), Message: event.Message}
+		for _, field := range event.Data {
+			value := field.Value
+			if err, ok := value.(error); ok {
+				// Errors are compared by message
+				value = err.Error()
+			}
+			if field.Type == zlog.StackType {
+				value = goldenStack
+			}
+			golden.Fields = append(golden.Fields, goldenField{Key: field.Key, Type: string(field.Type), Value: value})
+		}
+		if err := encoder.Encode(golden); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// AssertGolden compares the recorded events, as rendered by Encode, with
+// the golden file at path.
+func (r *Recorder) AssertGolden(t testing.TB, path string) {
+	t.Helper()
+
+	got, err := r.Encode()
+	if err != nil {
+		t.Fatalf("zlogtest: encoding recorded events: %v", err)
+	}
+	AssertGolden(t, path, got)
+}
+
+// AssertGolden compares got with the contents of the golden file at path,
+// typically the output of a sink writing to a buffer. Run the tests with
+// -zlogtest.update to create or rewrite the file instead.
+//
+//	var buf bytes.Buffer
+//	logger.Hook(zlog.INFO, newSink(&buf))
+//	logger.Info("started", zlog.Int("workers", 4))
+//	zlogtest.AssertGolden(t, "testdata/started.golden", buf.Bytes())
+func AssertGolden(t testing.TB, path string, got []byte) {
+	t.Helper()
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("zlogtest: creating golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o600); err != nil {
+			t.Fatalf("zlogtest: writing golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("zlogtest: reading golden file (run with -zlogtest.update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("zlogtest: output does not match %s\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
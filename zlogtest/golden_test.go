@@ -0,0 +1,49 @@
+package zlogtest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zoobzio/zlog"
+)
+
+func TestRecorderGolden(t *testing.T) {
+	rec := NewRecorder()
+	logger := zlog.New()
+	logger.HookAll(rec.Sink)
+	logger.Info("server started", zlog.Int("workers", 4), zlog.Strings("tags", []string{"api"}))
+	logger.Warn("slow request")
+	logger.Error("payment failed", zlog.Err(errors.New("card declined")))
+
+	rec.AssertGolden(t, "testdata/recorder.golden")
+}
+
+func TestAssertGoldenMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.golden")
+	if err := os.WriteFile(path, []byte("want\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	AssertGolden(t, path, []byte("want\n"))
+
+	fake := &fakeT{}
+	AssertGolden(fake, path, []byte("got\n"))
+	if len(fake.failures) != 1 {
+		t.Errorf("expected a mismatch failure, got %v", fake.failures)
+	}
+}
+
+func TestAssertGoldenUpdate(t *testing.T) {
+	*update = true
+	t.Cleanup(func() { *update = false })
+
+	path := filepath.Join(t.TempDir(), "nested", "out.golden")
+	AssertGolden(t, path, []byte("fresh\n"))
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "fresh\n" {
+		t.Errorf("golden file not written: %q, %v", data, err)
+	}
+}
@@ -0,0 +1,69 @@
+package zlogtest
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/zoobzio/zlog"
+)
+
+// current is the recorder installed by the innermost active Isolate.
+var current struct {
+	recorder *Recorder
+	mu       sync.Mutex
+}
+
+// Isolate gives the test a fresh default logger and returns a recorder that
+// sees every event emitted through it. The previous default logger is
+// restored when the test ends.
+//
+//	func TestSignup(t *testing.T) {
+//	    rec := zlogtest.Isolate(t)
+//	    signup("ada@example.com")
+//	    rec.AssertEmitted(t, USER_REGISTERED, zlogtest.Field("email", "ada@example.com"))
+//	}
+//
+// The package-level AssertEmitted and AssertNoSignal use this recorder.
+// The default logger is process-wide, so tests that call Isolate must not
+// run in parallel with each other.
+func Isolate(t testing.TB) *Recorder {
+	t.Helper()
+
+	rec := NewRecorder()
+	logger := zlog.New()
+	logger.HookAll(rec.Sink)
+
+	previous := zlog.Default()
+	zlog.SetDefault(logger)
+
+	current.mu.Lock()
+	outer := current.recorder
+	current.recorder = rec
+	current.mu.Unlock()
+
+	t.Cleanup(func() {
+		zlog.SetDefault(previous)
+		_ = logger.Shutdown(context.Background()) //nolint:errcheck // The recorder never fails
+
+		current.mu.Lock()
+		current.recorder = outer
+		current.mu.Unlock()
+	})
+	return rec
+}
+
+// isolated returns the recorder installed by Isolate, failing the test if
+// there is none.
+func isolated(t testing.TB) *Recorder {
+	t.Helper()
+
+	current.mu.Lock()
+	rec := current.recorder
+	current.mu.Unlock()
+
+	if rec == nil {
+		t.Fatal("zlogtest: call zlogtest.Isolate before using the package-level assertions")
+	}
+	return rec
+}
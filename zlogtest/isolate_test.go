@@ -0,0 +1,37 @@
+package zlogtest
+
+import (
+	"testing"
+
+	"github.com/zoobzio/zlog"
+)
+
+func TestIsolate(t *testing.T) {
+	previous := zlog.Default().Logger
+
+	t.Run("records the default logger", func(t *testing.T) {
+		rec := Isolate(t)
+		if zlog.Default().Logger == previous {
+			t.Fatal("Isolate did not replace the default logger")
+		}
+
+		zlog.Info("isolated", zlog.String("test", t.Name()))
+		zlog.Emit("CUSTOM_SIGNAL", "custom")
+
+		AssertEmitted(t, zlog.INFO, Field("test", t.Name()))
+		AssertEmitted(t, "CUSTOM_SIGNAL", Message("custom"))
+		AssertNoSignal(t, zlog.ERROR)
+		if rec.Len() != 2 {
+			t.Errorf("expected 2 events, got %d", rec.Len())
+		}
+	})
+
+	if zlog.Default().Logger != previous {
+		t.Error("Isolate did not restore the default logger")
+	}
+	current.mu.Lock()
+	defer current.mu.Unlock()
+	if current.recorder != nil {
+		t.Error("Isolate did not restore the package recorder")
+	}
+}
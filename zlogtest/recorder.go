@@ -0,0 +1,123 @@
+// Package zlogtest provides helpers for testing code that logs with zlog.
+//
+// A Recorder is a sink that keeps every event it receives. Isolate installs
+// a fresh default logger that records everything for the duration of a test:
+//
+//	func TestCheckout(t *testing.T) {
+//	    rec := zlogtest.Isolate(t)
+//
+//	    checkout(order)
+//
+//	    zlogtest.AssertEmitted(t, PAYMENT_PROCESSED, zlogtest.Field("order_id", "42"))
+//	    zlogtest.AssertNoSignal(t, zlog.ERROR)
+//	    rec.AssertGolden(t, "testdata/checkout.golden")
+//	}
+package zlogtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zoobzio/zlog"
+)
+
+// Recorder is a sink that records every event it receives.
+//
+// Hook it like any other sink; the embedded *zlog.Sink can also be wrapped
+// with adapters to test them:
+//
+//	rec := zlogtest.NewRecorder()
+//	zlog.Hook(zlog.ERROR, rec.Sink)
+//
+// Recorder is safe for concurrent use.
+type Recorder struct {
+	*zlog.Sink
+	notify chan struct{} // Closed and replaced whenever an event arrives
+	events []zlog.Log
+	mu     sync.Mutex
+}
+
+// NewRecorder creates an empty recorder.
+func NewRecorder() *Recorder {
+	r := &Recorder{notify: make(chan struct{})}
+	r.Sink = zlog.NewSink("zlogtest-recorder", func(_ context.Context, event zlog.Log) error {
+		r.record(event)
+		return nil
+	})
+	return r
+}
+
+// record stores an event and wakes any waiters.
+func (r *Recorder) record(event zlog.Log) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Keep our own copy of the fields so later mutation by the caller
+	// cannot change what was recorded
+	event.Data = append(zlog.Fields(nil), event.Data...)
+	r.events = append(r.events, event)
+
+	close(r.notify)
+	r.notify = make(chan struct{})
+}
+
+// Events returns a copy of the recorded events, in arrival order.
+func (r *Recorder) Events() []zlog.Log {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]zlog.Log(nil), r.events...)
+}
+
+// Signal returns the recorded events with the given signal.
+func (r *Recorder) Signal(signal zlog.Signal) []zlog.Log {
+	var events []zlog.Log
+	for _, event := range r.Events() {
+		if event.Signal == signal {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// Len returns the number of recorded events.
+func (r *Recorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.events)
+}
+
+// Reset discards the recorded events.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = nil
+}
+
+// Wait blocks until at least n events have been recorded or the timeout
+// expires. Use it before asserting on events delivered by async sinks:
+//
+//	if err := rec.Wait(3, time.Second); err != nil {
+//	    t.Fatal(err)
+//	}
+func (r *Recorder) Wait(n int, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		r.mu.Lock()
+		count, notify := len(r.events), r.notify
+		r.mu.Unlock()
+
+		if count >= n {
+			return nil
+		}
+
+		select {
+		case <-notify:
+		case <-timer.C:
+			return fmt.Errorf("zlogtest: recorded %d events, want %d after %s", r.Len(), n, timeout)
+		}
+	}
+}
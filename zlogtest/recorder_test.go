@@ -0,0 +1,59 @@
+package zlogtest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zoobzio/zlog"
+)
+
+func TestRecorder(t *testing.T) {
+	rec := NewRecorder()
+	logger := zlog.New()
+	logger.Hook(zlog.INFO, rec.Sink)
+	logger.Hook(zlog.ERROR, rec.Sink)
+
+	fields := []zlog.Field{zlog.String("user", "ada")}
+	logger.Info("first", fields...)
+	logger.Error("second")
+	fields[0] = zlog.String("user", "mutated")
+
+	if rec.Len() != 2 {
+		t.Fatalf("expected 2 events, got %d", rec.Len())
+	}
+	if got := rec.Events()[0].Data[0].Value; got != "ada" {
+		t.Errorf("recorded field changed with the caller's slice: %v", got)
+	}
+	if errors := rec.Signal(zlog.ERROR); len(errors) != 1 || errors[0].Message != "second" {
+		t.Errorf("unexpected ERROR events: %+v", errors)
+	}
+
+	rec.Reset()
+	if rec.Len() != 0 {
+		t.Errorf("expected no events after Reset, got %d", rec.Len())
+	}
+}
+
+func TestRecorderWait(t *testing.T) {
+	rec := NewRecorder()
+	async := rec.WithAsync()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = async.Process(context.Background(), zlog.NewEvent(zlog.INFO, "async", nil)) //nolint:errcheck // Test
+		}()
+	}
+	wg.Wait()
+
+	if err := rec.Wait(5, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Wait(6, 10*time.Millisecond); err == nil {
+		t.Error("expected Wait to time out")
+	}
+}